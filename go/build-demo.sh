@@ -77,3 +77,8 @@ cd ../newui/
 go build -o webui_demo
 mv ./webui_demo ../../demo/.
 cp -r ./web ../../demo/.
+
+echo "Building All In One"
+cd ../allinone/
+go build -o allinone_demo
+mv ./allinone_demo ../../demo/.
@@ -0,0 +1,281 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/saichler/l8types/go/ifs"
+	common2 "github.com/saichler/probler/go/prob/common"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Backoff between attempts to reach a cluster's API server.
+const (
+	clusterBackoffMin = 5 * time.Second
+	clusterBackoffMax = 5 * time.Minute
+)
+
+// kubeCluster is one cluster adcon collects: its own cluster, or one cluster
+// of the kubeconfig in out of cluster mode.
+type kubeCluster struct {
+	name   string
+	config *rest.Config
+	// credId names the credential of the cluster's kubeconfig for the
+	// collector; empty means the collector uses its in cluster config.
+	credId string
+	// kubeconfig is the cluster's context alone, its files inlined, as stored
+	// under credId.
+	kubeconfig []byte
+}
+
+// loadClusters returns adcon's own cluster, named by the ClusterName env
+// variable, when no kubeconfig is set. Otherwise every cluster of the
+// kubeconfig list, filtered by kubeContexts:
+//
+//   - a file is one cluster per context, named after the context;
+//   - a directory is one cluster per file, named after the file and using its
+//     current context, e.g. one mounted Secret key per edge cluster.
+func loadClusters(boot *common2.BootConfig) ([]*kubeCluster, error) {
+	if boot.Kubeconfig == "" {
+		cfg, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, err
+		}
+		return []*kubeCluster{{name: os.Getenv("ClusterName"), config: cfg}}, nil
+	}
+
+	wanted := make(map[string]bool)
+	for _, name := range strings.Split(boot.KubeContexts, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			wanted[name] = true
+		}
+	}
+	clusters := make([]*kubeCluster, 0)
+	for _, path := range filepath.SplitList(boot.Kubeconfig) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			list, err := contextClusters(path, wanted)
+			if err != nil {
+				return nil, err
+			}
+			clusters = append(clusters, list...)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			// Skips the ..data link and the timestamped directories of a
+			// mounted Secret, its keys are links to files.
+			if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			if len(wanted) > 0 && !wanted[e.Name()] {
+				continue
+			}
+			c, err := kubeconfigCluster(filepath.Join(path, e.Name()), "", e.Name())
+			if err != nil {
+				return nil, err
+			}
+			clusters = append(clusters, c)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, c := range clusters {
+		if seen[c.name] {
+			return nil, fmt.Errorf("cluster %s is in the kubeconfig more than once", c.name)
+		}
+		seen[c.name] = true
+		delete(wanted, c.name)
+	}
+	for name := range wanted {
+		return nil, fmt.Errorf("cluster %s is not in the kubeconfig", name)
+	}
+	if len(clusters) == 0 {
+		return nil, fmt.Errorf("no clusters in %s", boot.Kubeconfig)
+	}
+	return clusters, nil
+}
+
+// decommissionedClusters returns the kubeDecommission clusters. A cluster
+// cannot be collected and decommissioned at once.
+func decommissionedClusters(boot *common2.BootConfig, clusters []*kubeCluster) ([]string, error) {
+	collected := make(map[string]bool)
+	for _, c := range clusters {
+		collected[c.name] = true
+	}
+	names := make([]string, 0)
+	for _, name := range strings.Split(boot.KubeDecommission, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if collected[name] {
+			return nil, fmt.Errorf("cluster %s is both collected and decommissioned", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func contextClusters(file string, wanted map[string]bool) ([]*kubeCluster, error) {
+	kc, err := clientcmd.LoadFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(kc.Contexts))
+	for name := range kc.Contexts {
+		if len(wanted) == 0 || wanted[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	clusters := make([]*kubeCluster, 0, len(names))
+	for _, name := range names {
+		c, err := kubeconfigCluster(file, name, name)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, c)
+	}
+	return clusters, nil
+}
+
+// kubeconfigCluster builds the client config of one context, the current one
+// when context is empty. The config is read back from the kubeconfig stored as
+// the cluster's credential, so the collector gets what adcon itself uses.
+func kubeconfigCluster(file, context, name string) (*kubeCluster, error) {
+	kc, err := clientcmd.LoadFromFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", file, err.Error())
+	}
+	if context != "" {
+		kc.CurrentContext = context
+	}
+	// Keeps the context alone and inlines the certificate and key files, they
+	// are not on the collector's host.
+	if err = clientcmdapi.MinifyConfig(kc); err == nil {
+		err = clientcmdapi.FlattenConfig(kc)
+	}
+	var data []byte
+	if err == nil {
+		data, err = clientcmd.Write(*kc)
+	}
+	var cfg *rest.Config
+	if err == nil {
+		cfg, err = clientcmd.RESTConfigFromKubeConfig(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", file, err.Error())
+	}
+	return &kubeCluster{name: name, config: cfg, credId: kubeCredPrefix + name, kubeconfig: data}, nil
+}
+
+// runCluster waits until the cluster's API server answers, then reconciles the
+// targets of the selected kinds the cluster serves and keeps its summary until
+// stop is closed.
+func runCluster(nic ifs.IVNic, c *kubeCluster, sel *kindSelection, history *common2.ClusterHistory,
+	stop chan struct{}) {
+	var absent map[string]bool
+	ok := retryCluster(nic, c.name, "connect", stop, func() error {
+		clientset, err := kubernetes.NewForConfig(c.config)
+		if err == nil {
+			_, err = clientset.Discovery().ServerVersion()
+		}
+		if err == nil {
+			absent, err = absentCRDKinds(clientset.Discovery())
+		}
+		return err
+	})
+	if !ok {
+		return
+	}
+	if len(absent) > 0 {
+		names := make([]string, 0, len(absent))
+		for linksId := range absent {
+			names = append(names, linksId)
+		}
+		sort.Strings(names)
+		nic.Resources().Logger().Info("[ADCON] cluster ", c.name, " does not serve ", strings.Join(names, ","), ", not collected")
+	}
+	desired := desiredTargets(c, func(po *common2.PrimeObject) bool {
+		return sel.collects(po) && !absent[po.LinksId]
+	})
+	if c.credId != "" {
+		ok = retryCluster(nic, c.name, "store credential", stop, func() error {
+			return storeCredential(nic, c)
+		})
+		if !ok {
+			return
+		}
+	}
+	ok = retryCluster(nic, c.name, "reconcile targets", stop, func() error {
+		return reconcileTargets(nic, c.name, desired)
+	})
+	if !ok {
+		return
+	}
+
+	if sel.Namespaces != nil {
+		nic.Resources().Logger().Info("[ADCON] cluster ", c.name, " namespace selection applies to the summary only, every namespace is collected")
+	}
+	// Publish K8SCluster + K8SClusterSummary for the Overview tab as it changes.
+	publishClusterSummary(nic, c, sel, history, stop)
+}
+
+// retryCluster runs fn until it succeeds, backing off per cluster so an
+// unreachable edge cluster does not hold up the others. It returns false if
+// stop was closed first.
+func retryCluster(nic ifs.IVNic, cluster, what string, stop chan struct{}, fn func() error) bool {
+	return retry(nic, "cluster "+cluster+" "+what, stop, fn)
+}
+
+// retry runs fn until it succeeds or stop is closed, backing off between the
+// attempts.
+func retry(nic ifs.IVNic, what string, stop chan struct{}, fn func() error) bool {
+	backoff := clusterBackoffMin
+	for {
+		err := fn()
+		if err == nil {
+			return true
+		}
+		wait := backoff + time.Duration(rand.Int63n(int64(backoff/2)))
+		nic.Resources().Logger().Error("[ADCON] ", what, " failed, retry in ", wait.String(), ": ", err.Error())
+		select {
+		case <-stop:
+			return false
+		case <-time.After(wait):
+		}
+		if backoff *= 2; backoff > clusterBackoffMax {
+			backoff = clusterBackoffMax
+		}
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	common2 "github.com/saichler/probler/go/prob/common"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const testKubeconfig = `
+apiVersion: v1
+kind: Config
+current-context: edge
+clusters:
+- name: edge
+  cluster:
+    server: https://edge.example:6443
+    certificate-authority: ca.crt
+- name: lab
+  cluster:
+    server: https://lab.example:6443
+    insecure-skip-tls-verify: true
+users:
+- name: edge-admin
+  user:
+    token: edge-token
+- name: lab-admin
+  user:
+    token: lab-token
+contexts:
+- name: edge
+  context: {cluster: edge, user: edge-admin}
+- name: lab
+  context: {cluster: lab, user: lab-admin}
+`
+
+// TestKubeconfigCredential checks the credential stored for each context of a
+// kubeconfig reaches that cluster alone, without the files next to it.
+func TestKubeconfigCredential(t *testing.T) {
+	dir := t.TempDir()
+	ca := []byte("-----BEGIN CERTIFICATE-----\nedge\n-----END CERTIFICATE-----\n")
+	if err := os.WriteFile(filepath.Join(dir, "ca.crt"), ca, 0600); err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(dir, "config")
+	if err := os.WriteFile(filename, []byte(testKubeconfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	clusters, err := loadClusters(&common2.BootConfig{Kubeconfig: filename})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clusters) != 2 || clusters[0].name != "edge" || clusters[1].name != "lab" {
+		t.Fatal("expected the edge and lab clusters, got", len(clusters))
+	}
+	// The files are gone once loaded, the credential must not need them.
+	if err = os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		cluster *kubeCluster
+		host    string
+		token   string
+		ca      []byte
+	}{
+		{clusters[0], "https://edge.example:6443", "edge-token", ca},
+		{clusters[1], "https://lab.example:6443", "lab-token", nil},
+	} {
+		cred := kubeCredential(c.cluster)
+		if cred.Id != "kubeconfig-"+c.cluster.name || cred.Id != c.cluster.credId {
+			t.Fatal("unexpected credential id", cred.Id, "for", c.cluster.name)
+		}
+		item, ok := cred.Creds[c.cluster.name]
+		if !ok || len(cred.Creds) != 1 {
+			t.Fatal("expected one item named", c.cluster.name)
+		}
+		kc, err := clientcmd.Load([]byte(item.Aside))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(kc.Contexts) != 1 || len(kc.Clusters) != 1 || len(kc.AuthInfos) != 1 {
+			t.Fatal("the credential of", c.cluster.name, "should hold its context alone")
+		}
+		cfg, err := clientcmd.RESTConfigFromKubeConfig([]byte(item.Aside))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Host != c.host || cfg.BearerToken != c.token || !bytes.Equal(cfg.CAData, c.ca) {
+			t.Fatal("unexpected config for", c.cluster.name, cfg.Host, cfg.BearerToken)
+		}
+		if c.cluster.config.Host != cfg.Host || c.cluster.config.BearerToken != cfg.BearerToken {
+			t.Fatal("adcon and the collector should reach", c.cluster.name, "the same way")
+		}
+	}
+}
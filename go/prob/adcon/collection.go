@@ -0,0 +1,329 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/saichler/l8pollaris/go/pollaris"
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+	common2 "github.com/saichler/probler/go/prob/common"
+	types3 "github.com/saichler/probler/go/types"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/yaml"
+)
+
+// kindSelection picks the prime objects collected of a cluster. Entries are
+// links ids (K8sSec) or categories (Istio); an empty Include means all kinds
+// and Exclude wins over Include.
+type kindSelection struct {
+	Include    []string            `json:"include,omitempty"`
+	Exclude    []string            `json:"exclude,omitempty"`
+	Namespaces *namespaceSelection `json:"namespaces,omitempty"`
+}
+
+// namespaceSelection narrows the namespaces adcon counts in the cluster
+// summary, and nothing else. Entries are names or shell patterns (team-*); an
+// empty Include means all namespaces and Exclude wins over Include. The
+// collector's informers take no namespace from the target, so the inventory
+// still holds the objects of every namespace.
+type namespaceSelection struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// collectionConfig is the kubeCollection file, for example:
+//
+//	default:
+//	  exclude: [K8sSec, K8sEvt]
+//	  namespaces:
+//	    exclude: [kube-*]
+//	clusters:
+//	  edge-17:
+//	    include: [Workloads, Nodes, Namespaces]
+//	    namespaces:
+//	      include: [shop, payments]
+//	intervals:
+//	  K8sPod: 30s
+//	  Istio: 10m
+//
+// A cluster entry replaces the default's include and exclude it sets, for the
+// kinds and for the namespaces. Namespaces only narrow the cluster summary
+// counters; the collected kinds are collected in every namespace. Intervals are the poll cadence of a links id
+// or a category, a links id winning over its category. The polls of a kind are
+// shared by every cluster, so intervals apply to all of them.
+type collectionConfig struct {
+	Default   *kindSelection            `json:"default,omitempty"`
+	Clusters  map[string]*kindSelection `json:"clusters,omitempty"`
+	Intervals map[string]string         `json:"intervals,omitempty"`
+
+	intervals map[string]time.Duration
+}
+
+// loadCollection reads the kubeCollection file; no file collects every kind.
+func loadCollection(filename string) (*collectionConfig, error) {
+	cfg := &collectionConfig{}
+	if filename == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if err = yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err.Error())
+	}
+	if err = cfg.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err.Error())
+	}
+	return cfg, nil
+}
+
+// validate checks the kinds and namespace patterns and parses the intervals.
+func (this *collectionConfig) validate() error {
+	selections := []*kindSelection{this.Default}
+	for _, sel := range this.Clusters {
+		selections = append(selections, sel)
+	}
+	for _, sel := range selections {
+		if sel == nil {
+			continue
+		}
+		for _, name := range append(sel.Include, sel.Exclude...) {
+			if !knownKind(name) {
+				return fmt.Errorf("%s is neither a K8s links id nor a category", name)
+			}
+		}
+		if sel.Namespaces == nil {
+			continue
+		}
+		for _, pattern := range append(sel.Namespaces.Include, sel.Namespaces.Exclude...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("namespace %q: %s", pattern, err.Error())
+			}
+		}
+	}
+	this.intervals = make(map[string]time.Duration)
+	for name, value := range this.Intervals {
+		if !knownKind(name) {
+			return fmt.Errorf("interval of %s: neither a K8s links id nor a category", name)
+		}
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("interval of %s: %s", name, err.Error())
+		}
+		if interval < time.Second {
+			return fmt.Errorf("interval of %s: %s is shorter than a second", name, value)
+		}
+		this.intervals[name] = interval
+	}
+	return nil
+}
+
+func knownKind(name string) bool {
+	for _, po := range common2.K8sPrimeObjects {
+		if po.LinksId == name || po.Category == name {
+			return true
+		}
+	}
+	return false
+}
+
+// selection returns the selection of a cluster.
+func (this *collectionConfig) selection(cluster string) *kindSelection {
+	sel := &kindSelection{}
+	ns := &namespaceSelection{}
+	if this.Default != nil {
+		*sel = *this.Default
+		if this.Default.Namespaces != nil {
+			*ns = *this.Default.Namespaces
+		}
+	}
+	if override, ok := this.Clusters[cluster]; ok && override != nil {
+		if len(override.Include) > 0 {
+			sel.Include = override.Include
+		}
+		if len(override.Exclude) > 0 {
+			sel.Exclude = override.Exclude
+		}
+		if override.Namespaces != nil && len(override.Namespaces.Include) > 0 {
+			ns.Include = override.Namespaces.Include
+		}
+		if override.Namespaces != nil && len(override.Namespaces.Exclude) > 0 {
+			ns.Exclude = override.Namespaces.Exclude
+		}
+	}
+	sel.Namespaces = ns
+	return sel
+}
+
+// interval returns the poll interval of a kind, 0 to keep its boot polls'.
+func (this *collectionConfig) interval(po *common2.PrimeObject) time.Duration {
+	if interval, ok := this.intervals[po.LinksId]; ok {
+		return interval
+	}
+	return this.intervals[po.Category]
+}
+
+func (this *kindSelection) collects(po *common2.PrimeObject) bool {
+	matches := func(names []string) bool {
+		for _, name := range names {
+			if name == po.LinksId || name == po.Category {
+				return true
+			}
+		}
+		return false
+	}
+	if matches(this.Exclude) {
+		return false
+	}
+	return len(this.Include) == 0 || matches(this.Include)
+}
+
+// countsNamespace tells if objects of namespace are counted, cluster scoped
+// objects (no namespace) always are.
+func (this *kindSelection) countsNamespace(namespace string) bool {
+	if namespace == "" || this.Namespaces == nil {
+		return true
+	}
+	matches := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, namespace); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if matches(this.Namespaces.Exclude) {
+		return false
+	}
+	return len(this.Namespaces.Include) == 0 || matches(this.Namespaces.Include)
+}
+
+// counted wraps count so objects of namespaces the cluster does not select
+// are left out of its summary.
+func (this *kindSelection) counted(count countFunc) countFunc {
+	return func(obj interface{}, s *types3.K8SClusterSummary, d int32) {
+		if m, err := meta.Accessor(obj); err == nil && !this.countsNamespace(m.GetNamespace()) {
+			return
+		}
+		count(obj, s, d)
+	}
+}
+
+// applyPollIntervals sets the cadence of every poll of the kinds that have an
+// interval, through the pollaris service the collectors read their polls from.
+func applyPollIntervals(nic ifs.IVNic, cfg *collectionConfig, stop chan struct{}) {
+	intervals := make(map[string]time.Duration)
+	for _, po := range common2.K8sPrimeObjects {
+		if interval := cfg.interval(po); po.Collected && interval > 0 {
+			intervals[po.LinksId] = interval
+		}
+	}
+	linksIds := make([]string, 0, len(intervals))
+	for linksId := range intervals {
+		linksIds = append(linksIds, linksId)
+	}
+	sort.Strings(linksIds)
+	for _, linksId := range linksIds {
+		interval := intervals[linksId]
+		ok := retry(nic, "poll interval of "+linksId, stop, func() error {
+			return setPollInterval(nic, linksId, interval)
+		})
+		if !ok {
+			return
+		}
+		nic.Resources().Logger().Info("[ADCON] ", linksId, " polled every ", interval.String())
+	}
+}
+
+// setPollInterval replaces the cadence of the polls of the pollaris named
+// after a links id.
+func setPollInterval(nic ifs.IVNic, linksId string, interval time.Duration) error {
+	elems, err := object.NewQuery("select * from L8Pollaris where Name="+linksId, nic.Resources())
+	if err != nil {
+		return err
+	}
+	resp := nic.LeaderRequest(pollaris.ServiceName, pollaris.ServiceArea, ifs.GET, elems.(*object.Elements).PQuery(), targetsRequestTimeout)
+	if resp == nil {
+		return fmt.Errorf("no answer from %s", pollaris.ServiceName)
+	}
+	if resp.Error() != nil {
+		return resp.Error()
+	}
+	var polls *l8tpollaris.L8Pollaris
+	for _, e := range resp.Elements() {
+		if p, ok := e.(*l8tpollaris.L8Pollaris); ok && p.Name == linksId {
+			polls = proto.Clone(p).(*l8tpollaris.L8Pollaris)
+		}
+	}
+	if polls == nil {
+		return fmt.Errorf("no pollaris %s yet", linksId)
+	}
+	for _, poll := range polls.Polling {
+		poll.Cadence = &l8tpollaris.L8PCadencePlan{Cadences: []int64{int64(interval.Seconds())}, Enabled: true}
+	}
+	resp = nic.LeaderRequest(pollaris.ServiceName, pollaris.ServiceArea, ifs.PUT, polls, targetsRequestTimeout)
+	if resp == nil {
+		return fmt.Errorf("no answer from %s", pollaris.ServiceName)
+	}
+	return resp.Error()
+}
+
+// absentCRDKinds returns the CRD backed kinds the cluster does not serve.
+func absentCRDKinds(disc discovery.DiscoveryInterface) (map[string]bool, error) {
+	served := make(map[string]map[string]bool)
+	absent := make(map[string]bool)
+	for linksId, gvrs := range common2.K8sCRDKinds {
+		found := false
+		for _, gvr := range gvrs {
+			i := strings.LastIndex(gvr, "/")
+			gv, resource := gvr[:i], gvr[i+1:]
+			resources, ok := served[gv]
+			if !ok {
+				resources = make(map[string]bool)
+				list, err := disc.ServerResourcesForGroupVersion(gv)
+				if err != nil && !errors.IsNotFound(err) {
+					return nil, err
+				}
+				if list != nil {
+					for _, r := range list.APIResources {
+						resources[r.Name] = true
+					}
+				}
+				served[gv] = resources
+			}
+			if resources[resource] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			absent[linksId] = true
+		}
+	}
+	return absent, nil
+}
@@ -0,0 +1,182 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	common2 "github.com/saichler/probler/go/prob/common"
+	types3 "github.com/saichler/probler/go/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testCollection = `
+default:
+  exclude: [K8sSec, Istio]
+  namespaces:
+    exclude: [kube-*]
+clusters:
+  edge:
+    include: [Workloads, K8sNode]
+    namespaces:
+      include: [shop, pay-*]
+  lab:
+    exclude: [K8sEvt]
+    namespaces:
+      exclude: [scratch]
+intervals:
+  Workloads: 2m
+  K8sPod: 30s
+`
+
+func writeCollection(t *testing.T, data string) string {
+	filename := filepath.Join(t.TempDir(), "collection.yaml")
+	if err := os.WriteFile(filename, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func primeObject(t *testing.T, linksId string) *common2.PrimeObject {
+	for _, po := range common2.K8sPrimeObjects {
+		if po.LinksId == linksId {
+			return po
+		}
+	}
+	t.Fatal("no prime object", linksId)
+	return nil
+}
+
+func TestKindSelection(t *testing.T) {
+	cfg, err := loadCollection(writeCollection(t, testCollection))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		cluster  string
+		linksId  string
+		collects bool
+	}{
+		{"other", common2.K8sPod_Links_ID, true},
+		{"other", common2.K8sSec_Links_ID, false},
+		{"other", common2.IstioVs_Links_ID, false},
+		{"other", common2.K8sEvt_Links_ID, true},
+		// edge includes by category and links id, the default exclude stays.
+		{"edge", common2.K8sDeploy_Links_ID, true},
+		{"edge", common2.K8sNode_Links_ID, true},
+		{"edge", common2.K8sEvt_Links_ID, false},
+		// lab replaces the default exclude.
+		{"lab", common2.K8sSec_Links_ID, true},
+		{"lab", common2.IstioVs_Links_ID, true},
+		{"lab", common2.K8sEvt_Links_ID, false},
+	} {
+		if got := cfg.selection(c.cluster).collects(primeObject(t, c.linksId)); got != c.collects {
+			t.Error(c.cluster, c.linksId, ": expected collects", c.collects, "got", got)
+		}
+	}
+}
+
+func TestNamespaceSelection(t *testing.T) {
+	cfg, err := loadCollection(writeCollection(t, testCollection))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		cluster   string
+		namespace string
+		counts    bool
+	}{
+		{"other", "", true},
+		{"other", "shop", true},
+		{"other", "kube-system", false},
+		// edge includes by name and pattern, the default exclude stays.
+		{"edge", "shop", true},
+		{"edge", "pay-eu", true},
+		{"edge", "billing", false},
+		{"edge", "kube-public", false},
+		{"edge", "", true},
+		// lab replaces the default exclude.
+		{"lab", "kube-system", true},
+		{"lab", "scratch", false},
+	} {
+		if got := cfg.selection(c.cluster).countsNamespace(c.namespace); got != c.counts {
+			t.Error(c.cluster, c.namespace, ": expected counts", c.counts, "got", got)
+		}
+	}
+
+	sel := cfg.selection("edge")
+	count := sel.counted(func(obj interface{}, s *types3.K8SClusterSummary, d int32) {
+		s.TotalPods += d
+	})
+	s := &types3.K8SClusterSummary{}
+	for _, ns := range []string{"shop", "billing", "pay-us"} {
+		count(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: ns}}, s, 1)
+	}
+	if s.TotalPods != 2 {
+		t.Fatal("expected the billing pod to be left out, got", s.TotalPods)
+	}
+
+	if !(&collectionConfig{}).selection("any").countsNamespace("kube-system") {
+		t.Fatal("no collection file should count every namespace")
+	}
+}
+
+func TestPollIntervals(t *testing.T) {
+	cfg, err := loadCollection(writeCollection(t, testCollection))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		linksId  string
+		interval time.Duration
+	}{
+		{common2.K8sPod_Links_ID, 30 * time.Second},
+		{common2.K8sDeploy_Links_ID, 2 * time.Minute},
+		{common2.K8sNode_Links_ID, 0},
+	} {
+		if got := cfg.interval(primeObject(t, c.linksId)); got != c.interval {
+			t.Error(c.linksId, ": expected", c.interval, "got", got)
+		}
+	}
+}
+
+func TestLoadCollectionErrors(t *testing.T) {
+	for _, c := range []struct {
+		data string
+		err  string
+	}{
+		{"default:\n  include: [Pods]\n", "Pods is neither a K8s links id nor a category"},
+		{"default:\n  namespaces:\n    include: ['team-[']\n", "syntax error in pattern"},
+		{"intervals:\n  K8sPod: often\n", "interval of K8sPod"},
+		{"intervals:\n  K8sPod: 100ms\n", "shorter than a second"},
+		{"intervals:\n  Pods: 1m\n", "interval of Pods"},
+		{"default:\n  namespace:\n    include: [shop]\n", "namespace"},
+	} {
+		_, err := loadCollection(writeCollection(t, c.data))
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Error(c.data, ": expected", c.err, "got", err)
+		}
+	}
+	cfg, err := loadCollection("")
+	if err != nil || len(cfg.intervals) != 0 {
+		t.Fatal("no file should collect every kind at its boot interval, got", err)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8api"
+)
+
+// The credentials service of the security provider, the one the System
+// Credentials tab edits (/75/Creds).
+const (
+	credsServiceName = "Creds"
+	credsServiceArea = byte(75)
+)
+
+// kubeCredPrefix keeps the credentials adcon stores for the clusters of its
+// kubeconfig apart from the ones users enter, e.g. "lab".
+const kubeCredPrefix = "kubeconfig-"
+
+// kubeCredential is the credential of a kubeconfig cluster: one item, named
+// after the cluster's host, holding the cluster's kubeconfig.
+func kubeCredential(c *kubeCluster) *l8api.L8Credentials {
+	return &l8api.L8Credentials{
+		Id:   c.credId,
+		Name: "kubeconfig of cluster " + c.name,
+		Creds: map[string]*l8api.L8Credential{
+			c.name: {Aside: string(c.kubeconfig)},
+		},
+	}
+}
+
+// storeCredential stores the credential of a kubeconfig cluster with the
+// security provider before its targets reference it. It is stored on every
+// start, so it follows the kubeconfig.
+func storeCredential(nic ifs.IVNic, c *kubeCluster) error {
+	resp := nic.LeaderRequest(credsServiceName, credsServiceArea, ifs.POST, kubeCredential(c), targetsRequestTimeout)
+	if resp == nil {
+		return fmt.Errorf("no answer from %s", credsServiceName)
+	}
+	if resp.Error() != nil {
+		return fmt.Errorf("credential %s: %s", c.credId, resp.Error().Error())
+	}
+	return nil
+}
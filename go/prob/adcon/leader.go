@@ -0,0 +1,129 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/saichler/l8types/go/ifs"
+	common2 "github.com/saichler/probler/go/prob/common"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// Lease timing: a standby takes over at most leaseDuration after the active
+// replica stopped renewing, and right away when it shut down cleanly.
+const (
+	leaseDuration = 15 * time.Second
+	leaseRenew    = 10 * time.Second
+	leaseRetry    = 2 * time.Second
+)
+
+// serviceAccountNamespace holds the pod's namespace in a cluster.
+const serviceAccountNamespace = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// runActive runs active once this replica holds the leaderLease, so only one
+// adcon posts targets and publishes summaries. The others stay connected as
+// hot standbys. A leader that loses the lease exits, to restart as a standby,
+// since its collector and informers cannot be stopped half way. Without a
+// lease adcon is active right away.
+func runActive(res ifs.IResources, lease string, active func()) error {
+	if lease == "" {
+		active()
+		return nil
+	}
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return err
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	namespace, name, ok := strings.Cut(lease, "/")
+	if !ok {
+		name = lease
+		data, err := os.ReadFile(serviceAccountNamespace)
+		if err != nil {
+			return err
+		}
+		namespace = strings.TrimSpace(string(data))
+	}
+	identity, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Client:     clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+	}
+
+	var leading atomic.Bool
+	common2.AddMetric("probler_adcon_leader", "Whether this adcon replica is the active one.", func() float64 {
+		if leading.Load() {
+			return 1
+		}
+		return 0
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	common2.OnShutdown(common2.ShutdownIntake, "leader lease", func(context.Context) error {
+		cancel()
+		return nil
+	})
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		Name:            name,
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   leaseRenew,
+		RetryPeriod:     leaseRetry,
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {
+				leading.Store(true)
+				res.Logger().Info("[ADCON] ", identity, " holds lease ", namespace, "/", name, ", active")
+				active()
+			},
+			OnStoppedLeading: func() {
+				leading.Store(false)
+				if ctx.Err() != nil {
+					// Released on shutdown.
+					return
+				}
+				res.Logger().Error("[ADCON] ", identity, " lost lease ", namespace, "/", name, ", restarting as a standby")
+				os.Exit(1)
+			},
+			OnNewLeader: func(leader string) {
+				if leader != identity {
+					res.Logger().Info("[ADCON] ", leader, " is active, ", identity, " is a standby")
+				}
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	go elector.Run(ctx)
+	return nil
+}
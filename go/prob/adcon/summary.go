@@ -18,39 +18,58 @@ package main
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/saichler/l8pollaris/go/pollaris/targets"
 	"github.com/saichler/l8types/go/ifs"
 	common2 "github.com/saichler/probler/go/prob/common"
 	types3 "github.com/saichler/probler/go/types"
+	"google.golang.org/protobuf/proto"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
 )
 
-// summaryRefreshInterval is the cadence at which adcon recomputes the
-// K8SCluster summary and republishes it to the cluster cache.
-const summaryRefreshInterval = 30 * time.Second
+// summaryDebounce is how long adcon waits after a counter changed before it
+// publishes, so a burst of events (a rollout, a node drain) is one publish.
+const summaryDebounce = 2 * time.Second
 
-// publishClusterSummary periodically lists Kubernetes resources and publishes
-// a K8SCluster record (with K8SClusterSummary) to the cluster cache.
+// summaryHeartbeat is the longest the cluster history goes without a point
+// and the cluster cache without a publish while nothing changes.
+const summaryHeartbeat = time.Minute
+
+// publishClusterSummary keeps the K8SClusterSummary counters up to date from
+// shared informers and publishes a K8SCluster record of the cluster to the
+// cluster cache whenever a counter changed, and again every summaryHeartbeat,
+// until stop is closed. Every published summary is also recorded in the
+// cluster history when there is one. Objects of namespaces sel leaves out are
+// not counted.
 //
-// It uses dedicated client-go List calls (not the K8s collector's shared
-// informers) because the collector's runtime state (CollectorCache, shared
-// informer machinery) is package-internal and not accessible from outside
-// the k8sclient package. This is the documented fallback in the plan.
-func publishClusterSummary(nic ifs.IVNic, clusterName string) {
-	cfg, err := rest.InClusterConfig()
+// The summary owns its informer set because the K8s collector's informers
+// (CollectorCache, shared informer machinery) are package-internal to the
+// k8sclient package. Kinds that are only counted use metadata informers, so
+// e.g. Secrets and ConfigMaps cost their ObjectMeta and not their data; Pods
+// and Events are trimmed to the fields the counters read.
+func publishClusterSummary(nic ifs.IVNic, c *kubeCluster, sel *kindSelection, history *common2.ClusterHistory,
+	stop chan struct{}) {
+	clusterName := c.name
+	clientset, err := kubernetes.NewForConfig(c.config)
 	if err != nil {
-		nic.Resources().Logger().Error("[ADCON-SUMMARY] in-cluster config: ", err.Error())
+		nic.Resources().Logger().Error("[ADCON-SUMMARY] new clientset: ", err.Error())
 		return
 	}
-	clientset, err := kubernetes.NewForConfig(cfg)
+	metaClient, err := metadata.NewForConfig(c.config)
 	if err != nil {
-		nic.Resources().Logger().Error("[ADCON-SUMMARY] new clientset: ", err.Error())
+		nic.Resources().Logger().Error("[ADCON-SUMMARY] new metadata client: ", err.Error())
 		return
 	}
 
@@ -63,8 +82,7 @@ func publishClusterSummary(nic ifs.IVNic, clusterName string) {
 
 	cacheName, cacheArea := targets.Links.Cache(common2.K8sClust_Links_ID)
 
-	publish := func() {
-		summary := buildClusterSummary(clientset)
+	publish := func(summary *types3.K8SClusterSummary) error {
 		cluster := &types3.K8SCluster{
 			Name:       clusterName,
 			K8SVersion: k8sVersion,
@@ -73,201 +91,387 @@ func publishClusterSummary(nic ifs.IVNic, clusterName string) {
 		}
 		if err := nic.Leader(cacheName, cacheArea, ifs.PATCH, cluster); err != nil {
 			nic.Resources().Logger().Error("[ADCON-SUMMARY] publish: ", err.Error())
-			return
+			return err
 		}
-		fmt.Printf("[ADCON-SUMMARY] published cluster=%s nodes=%d/%d pods=%d/%d deploys=%d/%d\n",
+		nic.Resources().Logger().Info(fmt.Sprintf("[ADCON-SUMMARY] published cluster=%s nodes=%d/%d pods=%d/%d deploys=%d/%d",
 			clusterName,
 			summary.ReadyNodes, summary.TotalNodes,
 			summary.RunningPods, summary.TotalPods,
-			summary.AvailableDeployments, summary.TotalDeployments)
+			summary.AvailableDeployments, summary.TotalDeployments))
+		return nil
+	}
+
+	record := func(summary *types3.K8SClusterSummary) {}
+	if history != nil {
+		record = func(summary *types3.K8SClusterSummary) {
+			if err := history.Record(clusterName, time.Now(), summary); err != nil {
+				nic.Resources().Logger().Error("[ADCON-SUMMARY] record history: ", err.Error())
+			}
+		}
+	}
+
+	// A kind the API server does not serve, or RBAC does not let adcon list,
+	// gets no informer and its counters stay zero; its informer would never
+	// sync and hold every other counter back.
+	skip := func(gvr schema.GroupVersionResource) bool {
+		if err := summaryListable(metaClient, gvr); err != nil {
+			nic.Resources().Logger().Info("[ADCON-SUMMARY] cluster=", clusterName, " not counting ", gvr.String(), ": ", err.Error())
+			return true
+		}
+		return false
+	}
+
+	counter := newSummaryCounter()
+	factory := informers.NewSharedInformerFactory(clientset, 0)
+	metaFactory := metadatainformer.NewSharedInformerFactory(metaClient, 0)
+	synced := make(map[string]cache.InformerSynced)
+	for _, k := range typedSummaryKinds(factory) {
+		if skip(k.gvr) {
+			continue
+		}
+		informer := k.informer()
+		if k.trim != nil {
+			informer.SetTransform(k.trim)
+		}
+		informer.AddEventHandler(counter.handler(sel.counted(k.count)))
+		synced[k.gvr.String()] = informer.HasSynced
+	}
+	for _, k := range countedSummaryKinds {
+		if skip(k.gvr) {
+			continue
+		}
+		informer := metaFactory.ForResource(k.gvr).Informer()
+		field := k.field
+		informer.AddEventHandler(counter.handler(sel.counted(func(obj interface{}, s *types3.K8SClusterSummary, d int32) {
+			*field(s) += d
+		})))
+		synced[k.gvr.String()] = informer.HasSynced
+	}
+	factory.Start(stop)
+	metaFactory.Start(stop)
+	waitSummarySync(nic, clusterName, synced, stop)
+
+	counter.run(stop, summaryHeartbeat, publish, record)
+}
+
+// summarySyncTimeout bounds the wait for the first list of every informer,
+// the summary is published with the ones synced by then.
+const summarySyncTimeout = 2 * time.Minute
+
+// summaryListable lists one object of a kind, which fails when the API server
+// does not serve it or adcon may not list it.
+func summaryListable(metaClient metadata.Interface, gvr schema.GroupVersionResource) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	_, err := metaClient.Resource(gvr).List(ctx, metav1.ListOptions{Limit: 1})
+	return err
+}
+
+// waitSummarySync waits for every informer's first list, at most
+// summarySyncTimeout, and logs the ones still not synced.
+func waitSummarySync(nic ifs.IVNic, clusterName string, synced map[string]cache.InformerSynced, stop chan struct{}) {
+	timeout := make(chan struct{})
+	timer := time.AfterFunc(summarySyncTimeout, func() { close(timeout) })
+	defer timer.Stop()
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-stop:
+		case <-timeout:
+		}
+		close(done)
+	}()
+	for name, hasSynced := range synced {
+		if !cache.WaitForCacheSync(done, hasSynced) {
+			nic.Resources().Logger().Error("[ADCON-SUMMARY] cluster=", clusterName, " ", name, " not synced, counting it as it catches up")
+		}
+	}
+}
+
+// countFunc adds d (+1 or -1) to the counters obj contributes to.
+type countFunc func(obj interface{}, s *types3.K8SClusterSummary, d int32)
+
+// summaryCounter holds the live counters. Every informer event signals changed;
+// run publishes once the events settle and the counters differ from the last
+// published summary.
+type summaryCounter struct {
+	mtx     sync.Mutex
+	summary *types3.K8SClusterSummary
+	changed chan struct{}
+}
+
+func newSummaryCounter() *summaryCounter {
+	return &summaryCounter{summary: &types3.K8SClusterSummary{}, changed: make(chan struct{}, 1)}
+}
+
+func (this *summaryCounter) apply(fn func(s *types3.K8SClusterSummary)) {
+	this.mtx.Lock()
+	fn(this.summary)
+	this.mtx.Unlock()
+	select {
+	case this.changed <- struct{}{}:
+	default:
 	}
+}
+
+func (this *summaryCounter) handler(count countFunc) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			this.apply(func(s *types3.K8SClusterSummary) { count(obj, s, 1) })
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			this.apply(func(s *types3.K8SClusterSummary) {
+				count(oldObj, s, -1)
+				count(newObj, s, 1)
+			})
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tomb, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tomb.Obj
+			}
+			this.apply(func(s *types3.K8SClusterSummary) { count(obj, s, -1) })
+		},
+	}
+}
+
+func (this *summaryCounter) snapshot() *types3.K8SClusterSummary {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	return proto.Clone(this.summary).(*types3.K8SClusterSummary)
+}
 
-	publish()
-	ticker := time.NewTicker(summaryRefreshInterval)
+// run publishes the synced counters once and then after every settled burst
+// of changes, until stop is closed. Every heartbeat the current counters are
+// published again, so a failed publish, or a cluster cache that restarted,
+// catches up without waiting for a change. Every summary is recorded, whether
+// the publish succeeded or not.
+func (this *summaryCounter) run(stop chan struct{}, heartbeat time.Duration,
+	publish func(*types3.K8SClusterSummary) error, record func(*types3.K8SClusterSummary)) {
+	// last is the last summary the cluster cache accepted, nil until one was.
+	var last *types3.K8SClusterSummary
+	send := func(current *types3.K8SClusterSummary) {
+		if publish(current) == nil {
+			last = current
+		}
+		record(current)
+	}
+	send(this.snapshot())
+	ticker := time.NewTicker(heartbeat)
 	defer ticker.Stop()
-	for range ticker.C {
-		publish()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			send(this.snapshot())
+			continue
+		case <-this.changed:
+		}
+		select {
+		case <-stop:
+			return
+		case <-time.After(summaryDebounce):
+		}
+		// Events that arrived during the debounce are in the snapshot.
+		select {
+		case <-this.changed:
+		default:
+		}
+		current := this.snapshot()
+		if last != nil && proto.Equal(current, last) {
+			continue
+		}
+		send(current)
+		ticker.Reset(heartbeat)
 	}
 }
 
-// buildClusterSummary issues client-go List calls for every resource type the
-// Overview cards need and returns a populated K8SClusterSummary. Errors on a
-// single resource type are logged and that field stays at its zero value —
-// the rest of the summary still publishes.
-func buildClusterSummary(cs kubernetes.Interface) *types3.K8SClusterSummary {
-	ctx := context.Background()
-	opts := metav1.ListOptions{}
-	s := &types3.K8SClusterSummary{}
+// typedSummaryKind is a kind whose counters read more than the metadata. The
+// informer is only created, and so started by the factory, when the kind is
+// listable.
+type typedSummaryKind struct {
+	gvr      schema.GroupVersionResource
+	informer func() cache.SharedIndexInformer
+	count    countFunc
+	trim     cache.TransformFunc
+}
 
-	// Nodes
-	if l, err := cs.CoreV1().Nodes().List(ctx, opts); err == nil {
-		s.TotalNodes = int32(len(l.Items))
-		for i := range l.Items {
-			for _, c := range l.Items[i].Status.Conditions {
+// typedSummaryKinds are the kinds whose counters read more than the metadata.
+func typedSummaryKinds(f informers.SharedInformerFactory) []*typedSummaryKind {
+	return []*typedSummaryKind{
+		{gvr: schema.GroupVersionResource{Version: "v1", Resource: "nodes"}, informer: f.Core().V1().Nodes().Informer, count: func(obj interface{}, s *types3.K8SClusterSummary, d int32) {
+			n, ok := obj.(*corev1.Node)
+			if !ok {
+				return
+			}
+			s.TotalNodes += d
+			for _, c := range n.Status.Conditions {
 				if c.Type == corev1.NodeReady && c.Status == corev1.ConditionTrue {
-					s.ReadyNodes++
+					s.ReadyNodes += d
 					break
 				}
 			}
-		}
-	}
-	// Pods
-	if l, err := cs.CoreV1().Pods("").List(ctx, opts); err == nil {
-		s.TotalPods = int32(len(l.Items))
-		for i := range l.Items {
-			switch l.Items[i].Status.Phase {
+		}},
+		{gvr: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, informer: f.Core().V1().Pods().Informer, trim: trimPod, count: func(obj interface{}, s *types3.K8SClusterSummary, d int32) {
+			p, ok := obj.(*corev1.Pod)
+			if !ok {
+				return
+			}
+			s.TotalPods += d
+			switch p.Status.Phase {
 			case corev1.PodRunning:
-				s.RunningPods++
+				s.RunningPods += d
 			case corev1.PodPending:
-				s.PendingPods++
+				s.PendingPods += d
 			case corev1.PodFailed:
-				s.FailedPods++
+				s.FailedPods += d
 			}
-		}
-	}
-	// Deployments
-	if l, err := cs.AppsV1().Deployments("").List(ctx, opts); err == nil {
-		s.TotalDeployments = int32(len(l.Items))
-		for i := range l.Items {
-			d := &l.Items[i]
-			if d.Status.Replicas > 0 && d.Status.AvailableReplicas == d.Status.Replicas {
-				s.AvailableDeployments++
+		}},
+		{gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, informer: f.Apps().V1().Deployments().Informer, count: func(obj interface{}, s *types3.K8SClusterSummary, d int32) {
+			x, ok := obj.(*appsv1.Deployment)
+			if !ok {
+				return
 			}
-		}
-	}
-	// StatefulSets
-	if l, err := cs.AppsV1().StatefulSets("").List(ctx, opts); err == nil {
-		s.TotalStatefulsets = int32(len(l.Items))
-		for i := range l.Items {
-			x := &l.Items[i]
+			s.TotalDeployments += d
+			if x.Status.Replicas > 0 && x.Status.AvailableReplicas == x.Status.Replicas {
+				s.AvailableDeployments += d
+			}
+		}},
+		{gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}, informer: f.Apps().V1().StatefulSets().Informer, count: func(obj interface{}, s *types3.K8SClusterSummary, d int32) {
+			x, ok := obj.(*appsv1.StatefulSet)
+			if !ok {
+				return
+			}
+			s.TotalStatefulsets += d
 			if x.Status.Replicas > 0 && x.Status.ReadyReplicas == x.Status.Replicas {
-				s.ReadyStatefulsets++
+				s.ReadyStatefulsets += d
 			}
-		}
-	}
-	// DaemonSets
-	if l, err := cs.AppsV1().DaemonSets("").List(ctx, opts); err == nil {
-		s.TotalDaemonsets = int32(len(l.Items))
-		for i := range l.Items {
-			x := &l.Items[i]
-			if x.Status.DesiredNumberScheduled > 0 &&
-				x.Status.NumberReady == x.Status.DesiredNumberScheduled {
-				s.ReadyDaemonsets++
+		}},
+		{gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}, informer: f.Apps().V1().DaemonSets().Informer, count: func(obj interface{}, s *types3.K8SClusterSummary, d int32) {
+			x, ok := obj.(*appsv1.DaemonSet)
+			if !ok {
+				return
 			}
-		}
-	}
-	// ReplicaSets
-	if l, err := cs.AppsV1().ReplicaSets("").List(ctx, opts); err == nil {
-		s.TotalReplicasets = int32(len(l.Items))
-	}
-	// Jobs / CronJobs / HPAs
-	if l, err := cs.BatchV1().Jobs("").List(ctx, opts); err == nil {
-		s.TotalJobs = int32(len(l.Items))
-		for i := range l.Items {
-			if l.Items[i].Status.Active > 0 {
-				s.ActiveJobs++
+			s.TotalDaemonsets += d
+			if x.Status.DesiredNumberScheduled > 0 && x.Status.NumberReady == x.Status.DesiredNumberScheduled {
+				s.ReadyDaemonsets += d
 			}
-		}
-	}
-	if l, err := cs.BatchV1().CronJobs("").List(ctx, opts); err == nil {
-		s.TotalCronjobs = int32(len(l.Items))
-		for i := range l.Items {
-			if len(l.Items[i].Status.Active) > 0 {
-				s.ActiveCronjobs++
+		}},
+		{gvr: schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}, informer: f.Batch().V1().Jobs().Informer, count: func(obj interface{}, s *types3.K8SClusterSummary, d int32) {
+			x, ok := obj.(*batchv1.Job)
+			if !ok {
+				return
 			}
-		}
-	}
-	if l, err := cs.AutoscalingV2().HorizontalPodAutoscalers("").List(ctx, opts); err == nil {
-		s.TotalHpas = int32(len(l.Items))
-	}
-
-	// Networking
-	if l, err := cs.CoreV1().Services("").List(ctx, opts); err == nil {
-		s.TotalServices = int32(len(l.Items))
-	}
-	if l, err := cs.NetworkingV1().Ingresses("").List(ctx, opts); err == nil {
-		s.TotalIngresses = int32(len(l.Items))
-	}
-	if l, err := cs.NetworkingV1().NetworkPolicies("").List(ctx, opts); err == nil {
-		s.TotalNetworkpolicies = int32(len(l.Items))
-	}
-	if l, err := cs.CoreV1().Endpoints("").List(ctx, opts); err == nil {
-		s.TotalEndpoints = int32(len(l.Items))
-	}
-	if l, err := cs.DiscoveryV1().EndpointSlices("").List(ctx, opts); err == nil {
-		s.TotalEndpointslices = int32(len(l.Items))
-	}
-	if l, err := cs.NetworkingV1().IngressClasses().List(ctx, opts); err == nil {
-		s.TotalIngressclasses = int32(len(l.Items))
-	}
-
-	// Storage
-	if l, err := cs.CoreV1().PersistentVolumes().List(ctx, opts); err == nil {
-		s.TotalPersistentvolumes = int32(len(l.Items))
-		for i := range l.Items {
-			if l.Items[i].Status.Phase == corev1.VolumeBound {
-				s.BoundPersistentvolumes++
+			s.TotalJobs += d
+			if x.Status.Active > 0 {
+				s.ActiveJobs += d
 			}
-		}
-	}
-	if l, err := cs.CoreV1().PersistentVolumeClaims("").List(ctx, opts); err == nil {
-		s.TotalPvcs = int32(len(l.Items))
-		for i := range l.Items {
-			if l.Items[i].Status.Phase == corev1.ClaimBound {
-				s.BoundPvcs++
+		}},
+		{gvr: schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}, informer: f.Batch().V1().CronJobs().Informer, count: func(obj interface{}, s *types3.K8SClusterSummary, d int32) {
+			x, ok := obj.(*batchv1.CronJob)
+			if !ok {
+				return
 			}
-		}
-	}
-	if l, err := cs.StorageV1().StorageClasses().List(ctx, opts); err == nil {
-		s.TotalStorageclasses = int32(len(l.Items))
+			s.TotalCronjobs += d
+			if len(x.Status.Active) > 0 {
+				s.ActiveCronjobs += d
+			}
+		}},
+		{gvr: schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumes"}, informer: f.Core().V1().PersistentVolumes().Informer, count: func(obj interface{}, s *types3.K8SClusterSummary, d int32) {
+			x, ok := obj.(*corev1.PersistentVolume)
+			if !ok {
+				return
+			}
+			s.TotalPersistentvolumes += d
+			if x.Status.Phase == corev1.VolumeBound {
+				s.BoundPersistentvolumes += d
+			}
+		}},
+		{gvr: schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}, informer: f.Core().V1().PersistentVolumeClaims().Informer, count: func(obj interface{}, s *types3.K8SClusterSummary, d int32) {
+			x, ok := obj.(*corev1.PersistentVolumeClaim)
+			if !ok {
+				return
+			}
+			s.TotalPvcs += d
+			if x.Status.Phase == corev1.ClaimBound {
+				s.BoundPvcs += d
+			}
+		}},
+		{gvr: schema.GroupVersionResource{Version: "v1", Resource: "events"}, informer: f.Core().V1().Events().Informer, trim: trimEvent, count: func(obj interface{}, s *types3.K8SClusterSummary, d int32) {
+			x, ok := obj.(*corev1.Event)
+			if !ok {
+				return
+			}
+			s.TotalEvents += d
+			if x.Type == corev1.EventTypeWarning {
+				s.WarningEvents += d
+			}
+		}},
 	}
+}
 
-	// Configuration
-	if l, err := cs.CoreV1().ConfigMaps("").List(ctx, opts); err == nil {
-		s.TotalConfigmaps = int32(len(l.Items))
-	}
-	if l, err := cs.CoreV1().Secrets("").List(ctx, opts); err == nil {
-		s.TotalSecrets = int32(len(l.Items))
-	}
-	if l, err := cs.CoreV1().ResourceQuotas("").List(ctx, opts); err == nil {
-		s.TotalResourcequotas = int32(len(l.Items))
-	}
-	if l, err := cs.CoreV1().LimitRanges("").List(ctx, opts); err == nil {
-		s.TotalLimitranges = int32(len(l.Items))
-	}
-	if l, err := cs.PolicyV1().PodDisruptionBudgets("").List(ctx, opts); err == nil {
-		s.TotalPoddisruptionbudgets = int32(len(l.Items))
-	}
+// trimmedMeta keeps what the informer store needs to key and version an object.
+func trimmedMeta(m *metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: m.Name, Namespace: m.Namespace, UID: m.UID, ResourceVersion: m.ResourceVersion}
+}
 
-	// Access Control
-	if l, err := cs.CoreV1().ServiceAccounts("").List(ctx, opts); err == nil {
-		s.TotalServiceaccounts = int32(len(l.Items))
-	}
-	if l, err := cs.RbacV1().Roles("").List(ctx, opts); err == nil {
-		s.TotalRoles = int32(len(l.Items))
-	}
-	if l, err := cs.RbacV1().ClusterRoles().List(ctx, opts); err == nil {
-		s.TotalClusterroles = int32(len(l.Items))
-	}
-	if l, err := cs.RbacV1().RoleBindings("").List(ctx, opts); err == nil {
-		s.TotalRolebindings = int32(len(l.Items))
-	}
-	if l, err := cs.RbacV1().ClusterRoleBindings().List(ctx, opts); err == nil {
-		s.TotalClusterrolebindings = int32(len(l.Items))
+func trimPod(obj interface{}) (interface{}, error) {
+	if p, ok := obj.(*corev1.Pod); ok {
+		return &corev1.Pod{ObjectMeta: trimmedMeta(&p.ObjectMeta), Status: corev1.PodStatus{Phase: p.Status.Phase}}, nil
 	}
+	return obj, nil
+}
 
-	// Top level
-	if l, err := cs.CoreV1().Namespaces().List(ctx, opts); err == nil {
-		s.TotalNamespaces = int32(len(l.Items))
-	}
-	if l, err := cs.CoreV1().Events("").List(ctx, opts); err == nil {
-		s.TotalEvents = int32(len(l.Items))
-		for i := range l.Items {
-			if l.Items[i].Type == corev1.EventTypeWarning {
-				s.WarningEvents++
-			}
-		}
+func trimEvent(obj interface{}) (interface{}, error) {
+	if e, ok := obj.(*corev1.Event); ok {
+		return &corev1.Event{ObjectMeta: trimmedMeta(&e.ObjectMeta), Type: e.Type}, nil
 	}
+	return obj, nil
+}
 
-	return s
+// countedSummaryKinds are only counted, through metadata informers.
+var countedSummaryKinds = []struct {
+	gvr   schema.GroupVersionResource
+	field func(s *types3.K8SClusterSummary) *int32
+}{
+	{schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalReplicasets }},
+	{schema.GroupVersionResource{Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalHpas }},
+	{schema.GroupVersionResource{Version: "v1", Resource: "services"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalServices }},
+	{schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalIngresses }},
+	{schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalNetworkpolicies }},
+	{schema.GroupVersionResource{Version: "v1", Resource: "endpoints"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalEndpoints }},
+	{schema.GroupVersionResource{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalEndpointslices }},
+	{schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingressclasses"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalIngressclasses }},
+	{schema.GroupVersionResource{Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalStorageclasses }},
+	{schema.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalConfigmaps }},
+	{schema.GroupVersionResource{Version: "v1", Resource: "secrets"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalSecrets }},
+	{schema.GroupVersionResource{Version: "v1", Resource: "resourcequotas"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalResourcequotas }},
+	{schema.GroupVersionResource{Version: "v1", Resource: "limitranges"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalLimitranges }},
+	{schema.GroupVersionResource{Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalPoddisruptionbudgets }},
+	{schema.GroupVersionResource{Version: "v1", Resource: "serviceaccounts"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalServiceaccounts }},
+	{schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "roles"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalRoles }},
+	{schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalClusterroles }},
+	{schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "rolebindings"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalRolebindings }},
+	{schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterrolebindings"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalClusterrolebindings }},
+	{schema.GroupVersionResource{Version: "v1", Resource: "namespaces"},
+		func(s *types3.K8SClusterSummary) *int32 { return &s.TotalNamespaces }},
 }
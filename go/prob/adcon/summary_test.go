@@ -0,0 +1,65 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	types3 "github.com/saichler/probler/go/types"
+)
+
+// TestSummaryRepublish checks a failed publish is retried on the heartbeat and
+// every summary is recorded.
+func TestSummaryRepublish(t *testing.T) {
+	counter := newSummaryCounter()
+	counter.apply(func(s *types3.K8SClusterSummary) { s.TotalPods = 3 })
+	<-counter.changed
+
+	published := make(chan *types3.K8SClusterSummary, 10)
+	recorded := make(chan *types3.K8SClusterSummary, 10)
+	attempts := 0
+	publish := func(s *types3.K8SClusterSummary) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("no leader")
+		}
+		published <- s
+		return nil
+	}
+	record := func(s *types3.K8SClusterSummary) { recorded <- s }
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go counter.run(stop, 10*time.Millisecond, publish, record)
+
+	select {
+	case s := <-published:
+		if s.TotalPods != 3 {
+			t.Fatal("expected the counters to be republished, got", s)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the failed publish was not retried on the heartbeat")
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-recorded:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the failed and the retried summary to be recorded")
+		}
+	}
+}
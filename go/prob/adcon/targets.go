@@ -0,0 +1,197 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/saichler/l8pollaris/go/pollaris/targets"
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+	common2 "github.com/saichler/probler/go/prob/common"
+	types3 "github.com/saichler/probler/go/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// targetsRequestTimeout is how many seconds adcon waits for the targets
+// service to list a cluster's targets or to take a change to one.
+const targetsRequestTimeout = 30
+
+// desiredTargets are the targets of a cluster: one per collected prime object
+// the cluster selects,
+// so that JobsQueue.target.LinksId is per-PrimeObject and CJobs route to the
+// matching parser/inventory cache. The cluster summary is not collected: there
+// is no Pollaris named "K8sClust" (cluster summary has no API poll), so posting
+// that target would fall back to the legacy "run all Pollarises" path and
+// waste cycles.
+func desiredTargets(c *kubeCluster, selected func(po *common2.PrimeObject) bool) map[string]*l8tpollaris.L8PTarget {
+	desired := make(map[string]*l8tpollaris.L8PTarget)
+	for _, po := range common2.K8sPrimeObjects {
+		if !po.Collected || !selected(po) {
+			continue
+		}
+		t := newK8sTarget(c, po.LinksId)
+		t.State = l8tpollaris.L8PTargetState_Up
+		desired[t.TargetId] = t
+	}
+	return desired
+}
+
+// existingTargets lists the targets the targets service holds for a cluster,
+// i.e. whose TargetId is <cluster>/<links id>.
+func existingTargets(nic ifs.IVNic, cluster string) (map[string]*l8tpollaris.L8PTarget, error) {
+	prefix := cluster + "/"
+	elems, err := object.NewQuery("select * from L8PTarget where TargetId="+prefix+"*", nic.Resources())
+	if err != nil {
+		return nil, err
+	}
+	resp := nic.LeaderRequest(targets.ServiceName, 0, ifs.GET, elems.(*object.Elements).PQuery(), targetsRequestTimeout)
+	if resp == nil {
+		return nil, fmt.Errorf("no answer from %s", targets.ServiceName)
+	}
+	if resp.Error() != nil {
+		return nil, resp.Error()
+	}
+	existing := make(map[string]*l8tpollaris.L8PTarget)
+	add := func(t *l8tpollaris.L8PTarget) {
+		if strings.HasPrefix(t.TargetId, prefix) {
+			existing[t.TargetId] = t
+		}
+	}
+	for _, e := range resp.Elements() {
+		switch v := e.(type) {
+		case *l8tpollaris.L8PTargetList:
+			for _, t := range v.List {
+				add(t)
+			}
+		case *l8tpollaris.L8PTarget:
+			add(v)
+		}
+	}
+	return existing, nil
+}
+
+// sameTarget compares the fields adcon sets; the state and whatever the
+// collector keeps on a target are not adcon's.
+func sameTarget(a, b *l8tpollaris.L8PTarget) bool {
+	return a.LinksId == b.LinksId && a.InventoryType == b.InventoryType &&
+		len(a.Hosts) == len(b.Hosts) && proto.Equal(
+		&l8tpollaris.L8PTarget{Hosts: a.Hosts}, &l8tpollaris.L8PTarget{Hosts: b.Hosts})
+}
+
+// targetChanges are the writes that bring the targets of a cluster to the
+// desired ones, each list in TargetId order.
+type targetChanges struct {
+	create    []*l8tpollaris.L8PTarget
+	update    []*l8tpollaris.L8PTarget
+	remove    []*l8tpollaris.L8PTarget
+	unchanged int
+}
+
+// diffTargets creates the missing targets, updates the changed ones keeping
+// their state, so a target disabled by hand stays down, and removes the ones
+// of kinds no longer collected. Unchanged targets are left out.
+func diffTargets(desired, existing map[string]*l8tpollaris.L8PTarget) *targetChanges {
+	changes := &targetChanges{}
+	for _, id := range sortedTargetIds(desired) {
+		t := desired[id]
+		old, ok := existing[id]
+		if !ok {
+			changes.create = append(changes.create, t)
+			continue
+		}
+		if sameTarget(old, t) {
+			changes.unchanged++
+			continue
+		}
+		t = proto.Clone(t).(*l8tpollaris.L8PTarget)
+		t.State = old.State
+		changes.update = append(changes.update, t)
+	}
+	for _, id := range sortedTargetIds(existing) {
+		if _, ok := desired[id]; !ok {
+			changes.remove = append(changes.remove, existing[id])
+		}
+	}
+	return changes
+}
+
+func sortedTargetIds(m map[string]*l8tpollaris.L8PTarget) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// reconcileTargets brings the targets of a cluster to desired through the
+// targets service, the same one they are listed from. It stops at the first
+// write the service refuses; the retry lists the targets again, so the writes
+// that went through are not repeated.
+func reconcileTargets(nic ifs.IVNic, cluster string, desired map[string]*l8tpollaris.L8PTarget) error {
+	existing, err := existingTargets(nic, cluster)
+	if err != nil {
+		return err
+	}
+	changes := diffTargets(desired, existing)
+	for _, w := range []struct {
+		what   string
+		action ifs.Action
+		list   []*l8tpollaris.L8PTarget
+	}{{"create", ifs.POST, changes.create}, {"update", ifs.PUT, changes.update}, {"delete", ifs.DELETE, changes.remove}} {
+		for _, t := range w.list {
+			if err = writeTarget(nic, w.what, w.action, t); err != nil {
+				return err
+			}
+		}
+	}
+	nic.Resources().Logger().Info("[ADCON] cluster ", cluster, " targets: ", len(changes.create), " created, ",
+		len(changes.update), " updated, ", len(changes.remove), " deleted, ", changes.unchanged, " unchanged")
+	return nil
+}
+
+// writeTarget sends one change to the targets service.
+func writeTarget(nic ifs.IVNic, what string, action ifs.Action, t *l8tpollaris.L8PTarget) error {
+	resp := nic.LeaderRequest(targets.ServiceName, 0, action, t, targetsRequestTimeout)
+	if resp == nil {
+		return fmt.Errorf("no answer from %s to the %s of %s", targets.ServiceName, what, t.TargetId)
+	}
+	if resp.Error() != nil {
+		return fmt.Errorf("%s %s: %s", what, t.TargetId, resp.Error().Error())
+	}
+	return nil
+}
+
+// decommissionCluster removes every target of a cluster adcon no longer
+// collects, and its record from the cluster cache.
+func decommissionCluster(nic ifs.IVNic, cluster string, stop chan struct{}) {
+	ok := retryCluster(nic, cluster, "decommission", stop, func() error {
+		return reconcileTargets(nic, cluster, map[string]*l8tpollaris.L8PTarget{})
+	})
+	if !ok {
+		return
+	}
+	cacheName, cacheArea := targets.Links.Cache(common2.K8sClust_Links_ID)
+	if err := nic.Leader(cacheName, cacheArea, ifs.DELETE, &types3.K8SCluster{Name: cluster}); err != nil {
+		nic.Resources().Logger().Error("[ADCON] remove cluster ", cluster, ": ", err.Error())
+		return
+	}
+	nic.Resources().Logger().Info("[ADCON] decommissioned cluster ", cluster)
+}
@@ -0,0 +1,85 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"testing"
+
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+)
+
+func testTarget(id, host string, state l8tpollaris.L8PTargetState) *l8tpollaris.L8PTarget {
+	t := &l8tpollaris.L8PTarget{TargetId: id, LinksId: id, State: state,
+		InventoryType: l8tpollaris.L8PTargetType_K8s_Cluster}
+	t.Hosts = map[string]*l8tpollaris.L8PHost{host: {HostId: host}}
+	return t
+}
+
+func targetIds(list []*l8tpollaris.L8PTarget) []string {
+	ids := make([]string, 0, len(list))
+	for _, t := range list {
+		ids = append(ids, t.TargetId)
+	}
+	return ids
+}
+
+func TestDiffTargets(t *testing.T) {
+	up, down := l8tpollaris.L8PTargetState_Up, l8tpollaris.L8PTargetState_Down
+	desired := map[string]*l8tpollaris.L8PTarget{
+		"c1/same":    testTarget("c1/same", "c1", up),
+		"c1/moved":   testTarget("c1/moved", "c2", up),
+		"c1/missing": testTarget("c1/missing", "c1", up),
+		"c1/added":   testTarget("c1/added", "c1", up),
+	}
+	existing := map[string]*l8tpollaris.L8PTarget{
+		// Disabled by hand, the state is not compared.
+		"c1/same":  testTarget("c1/same", "c1", down),
+		"c1/moved": testTarget("c1/moved", "c1", down),
+		"c1/gone":  testTarget("c1/gone", "c1", up),
+	}
+	changes := diffTargets(desired, existing)
+
+	if ids := targetIds(changes.create); len(ids) != 2 || ids[0] != "c1/added" || ids[1] != "c1/missing" {
+		t.Fatal("expected c1/added and c1/missing to be created, got", ids)
+	}
+	if len(changes.update) != 1 || changes.update[0].TargetId != "c1/moved" {
+		t.Fatal("expected c1/moved to be updated, got", targetIds(changes.update))
+	}
+	if changes.update[0].State != down {
+		t.Fatal("the update should keep the existing state, got", changes.update[0].State)
+	}
+	if _, ok := changes.update[0].Hosts["c2"]; !ok {
+		t.Fatal("the update should carry the desired hosts")
+	}
+	if desired["c1/moved"].State != up {
+		t.Fatal("the desired target should not be changed")
+	}
+	if ids := targetIds(changes.remove); len(ids) != 1 || ids[0] != "c1/gone" {
+		t.Fatal("expected c1/gone to be deleted, got", ids)
+	}
+	if changes.unchanged != 1 {
+		t.Fatal("expected c1/same to be unchanged, got", changes.unchanged)
+	}
+
+	changes = diffTargets(map[string]*l8tpollaris.L8PTarget{}, existing)
+	if len(changes.create) != 0 || len(changes.update) != 0 || len(changes.remove) != len(existing) {
+		t.Fatal("decommissioning should only delete, got", changes)
+	}
+	changes = diffTargets(desired, desired)
+	if len(changes.create)+len(changes.update)+len(changes.remove) != 0 || changes.unchanged != len(desired) {
+		t.Fatal("a reconciled cluster should need no writes, got", changes)
+	}
+}
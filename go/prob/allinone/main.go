@@ -0,0 +1,90 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"github.com/saichler/l8alarms/go/alm/ui"
+	"github.com/saichler/l8bus/go/overlay/vnet"
+	"github.com/saichler/l8bus/go/overlay/vnic"
+	common2 "github.com/saichler/l8common/go/common"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/probler/go/prob/common"
+	"github.com/saichler/probler/go/prob/common/activate"
+	"os"
+)
+
+// The all in one binary runs the vnet and every probler service in one
+// process, each service group on its own vnic so they show up in prctl top
+// like the separate binaries. The stores are Postgres, started locally with
+// /start-postgres.sh or, with --db-host, an already running one. It refuses
+// the memory data store, whose services cannot hold the events and alarms.
+func main() {
+	common.RegisterBootSettings(common.BootDb)
+	res := common.CreateResources("allinone", nil, os.Args[1:]...)
+	if common.Boot().DataStoreBackend == common.BackendMemory {
+		res.Logger().Error("The memory data store cannot hold the events and alarms, run with the postgres backend")
+		os.Exit(1)
+	}
+	ifs.SetNetworkMode(ifs.NETWORK_K8s)
+	net := vnet.NewVNet(res)
+	net.Start()
+	common.OnShutdown(common.ShutdownNetwork, "vnet", func(ctx context.Context) error {
+		net.Shutdown()
+		return nil
+	})
+	res.Logger().Info("vnet started!")
+
+	start("orm", nil, activate.Orm)
+	start("alm", ui.RegisterAlmTypes, activate.Alarms)
+	start("collector", nil, always(activate.Collector))
+	start("parser", nil, always(activate.Parser))
+	start("box", nil, always(activate.Box))
+	start("gpu", nil, always(activate.Gpu))
+	start("k8s", nil, always(activate.K8sInventory))
+	start("topo", nil, always(activate.Topology))
+
+	go common2.CreateWebServer("web", activate.WebTypes).Start()
+
+	common.WaitForSignal(res)
+}
+
+// start connects one more vnic to the in process vnet and activates a service
+// group on it. register, if set, runs before the vnic starts. A group that
+// fails to activate stops the process.
+func start(alias string, register func(ifs.IResources), activateOn func(ifs.IVNic) error) {
+	res := common.NewResources(alias)
+	if register != nil {
+		register(res)
+	}
+	nic := vnic.NewVirtualNetworkInterface(res, nil)
+	nic.Start()
+	activate.Vnic(nic)
+	nic.WaitForConnection()
+	if err := activateOn(nic); err != nil {
+		res.Logger().Error(alias, ": ", err.Error())
+		os.Exit(1)
+	}
+	res.Logger().Info(alias, " services activated!")
+}
+
+// always adapts an activation that cannot fail to start.
+func always(activateOn func(ifs.IVNic)) func(ifs.IVNic) error {
+	return func(nic ifs.IVNic) error {
+		activateOn(nic)
+		return nil
+	}
+}
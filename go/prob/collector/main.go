@@ -17,26 +17,24 @@ package main
 
 import (
 	"github.com/saichler/l8bus/go/overlay/vnic"
-	"github.com/saichler/l8collector/go/collector/common"
-	"github.com/saichler/l8collector/go/collector/service"
-	"github.com/saichler/l8pollaris/go/pollaris"
 	"github.com/saichler/l8types/go/ifs"
 	common2 "github.com/saichler/probler/go/prob/common"
+	"github.com/saichler/probler/go/prob/common/activate"
+	"os"
 )
 
 func main() {
-	common.SmoothFirstCollection = true
-	res := common2.CreateResources("collector")
+	// The collector logs every poll, so it only logs errors unless asked to.
+	res := common2.CreateResources("collector", func(boot *common2.BootConfig, res ifs.IResources) {
+		boot.LogLevel = "error"
+	}, os.Args[1:]...)
 	ifs.SetNetworkMode(ifs.NETWORK_K8s)
 	nic := vnic.NewVirtualNetworkInterface(res, nil)
 	nic.Start()
+	activate.Vnic(nic)
 	nic.WaitForConnection()
 
-	//Activate pollaris
-	pollaris.Activate(nic)
+	activate.Collector(nic)
 
-	//no need to activate with links id k8s as they are the same area for collection
-	service.Activate(common2.NetworkDevice_Links_ID, nic)
-	res.Logger().SetLogLevel(ifs.Error_Level)
 	common2.WaitForSignal(res)
 }
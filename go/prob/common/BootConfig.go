@@ -0,0 +1,452 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/saichler/l8types/go/ifs"
+	"sigs.k8s.io/yaml"
+)
+
+// BootConfigEnv names the YAML bootstrap file, see BootConfig.
+const BootConfigEnv = "PROBLER_CONFIG"
+
+// BootConfig holds the process settings a deployment may tune without a
+// rebuild. CreateResources layers it as defaults, then the YAML file named by
+// --config or PROBLER_CONFIG, then the PROBLER_* environment variables, then
+// the command line flags; a later layer overrides an earlier one and zero
+// values are left to the previous layer. Settings of a group only some
+// binaries use, like the database or adcon ones, are parsed only by the
+// binaries that register the group, see RegisterBootSettings. For example:
+//
+//	logLevel: debug
+//	keepAliveSeconds: 15
+//	vnetPort: 26000
+//	dataStore: problerdb
+//	txQueueSize: 50000
+type BootConfig struct {
+	LogLevel         string `json:"logLevel,omitempty"`
+	KeepAliveSeconds int64  `json:"keepAliveSeconds,omitempty"`
+	VnetHost         string `json:"vnetHost,omitempty"`
+	VnetPort         uint32 `json:"vnetPort,omitempty"`
+	DataStore        string `json:"dataStore,omitempty"`
+	DataStoreBackend string `json:"dataStoreBackend,omitempty"`
+	TimeSeriesStore  string `json:"timeSeriesStore,omitempty"`
+	TxQueueSize      uint64 `json:"txQueueSize,omitempty"`
+	RxQueueSize      uint64 `json:"rxQueueSize,omitempty"`
+	ShutdownSeconds  int64  `json:"shutdownSeconds,omitempty"`
+	ProbePort        uint32 `json:"probePort,omitempty"`
+	DbHost           string `json:"dbHost,omitempty"`
+	DbStartSeconds   int64  `json:"dbStartSeconds,omitempty"`
+	DbMigrations     string `json:"dbMigrations,omitempty"`
+	DbSslMode        string `json:"dbSslMode,omitempty"`
+	Kubeconfig       string `json:"kubeconfig,omitempty"`
+	KubeContexts     string `json:"kubeContexts,omitempty"`
+	KubeDecommission string `json:"kubeDecommission,omitempty"`
+	KubeCollection   string `json:"kubeCollection,omitempty"`
+	LeaderLease      string `json:"leaderLease,omitempty"`
+
+	groups  map[string]bool
+	sources map[string]string
+}
+
+// Boot setting groups, see RegisterBootSettings. The settings of no group are
+// parsed by every binary.
+const (
+	BootDb    = "db"
+	BootAdcon = "adcon"
+)
+
+// bootGroups are the groups the binary registered.
+var bootGroups = make([]string, 0)
+
+// RegisterBootSettings adds the settings of the given groups to the ones the
+// binary parses. It must be called before CreateResources.
+func RegisterBootSettings(groups ...string) {
+	bootGroups = append(bootGroups, groups...)
+}
+
+// NewBootConfig returns an empty BootConfig parsing the settings of no group
+// plus the ones of groups.
+func NewBootConfig(groups ...string) *BootConfig {
+	cfg := &BootConfig{groups: make(map[string]bool)}
+	for _, group := range groups {
+		cfg.groups[group] = true
+	}
+	return cfg
+}
+
+// bootSetting is one BootConfig field with its env variable and flag.
+type bootSetting struct {
+	key   string
+	env   string
+	group string
+	usage string
+	get   func(*BootConfig) string
+	set   func(*BootConfig, string) error
+}
+
+// settings returns the settings this config parses.
+func (this *BootConfig) settings() []*bootSetting {
+	settings := make([]*bootSetting, 0, len(bootSettings))
+	for _, s := range bootSettings {
+		if s.group == "" || this.groups[s.group] {
+			settings = append(settings, s)
+		}
+	}
+	return settings
+}
+
+var bootSettings = []*bootSetting{
+	{"logLevel", "PROBLER_LOG_LEVEL", "", "log level: trace, debug, info, warning or error",
+		func(c *BootConfig) string { return c.LogLevel },
+		func(c *BootConfig, v string) error {
+			if _, err := ParseLogLevel(v); err != nil {
+				return err
+			}
+			c.LogLevel = strings.ToLower(v)
+			return nil
+		}},
+	{"keepAliveSeconds", "PROBLER_KEEPALIVE_SECONDS", "", "seconds between keepalive messages",
+		func(c *BootConfig) string { return formatUint(uint64(c.KeepAliveSeconds)) },
+		func(c *BootConfig, v string) error {
+			n, err := strconv.ParseInt(v, 10, 64)
+			c.KeepAliveSeconds = n
+			return err
+		}},
+	{"vnetHost", "PROBLER_VNET_HOST", "", "remote vnet host to connect to",
+		func(c *BootConfig) string { return c.VnetHost },
+		func(c *BootConfig, v string) error {
+			c.VnetHost = v
+			return nil
+		}},
+	{"vnetPort", "PROBLER_VNET_PORT", "", "vnet port",
+		func(c *BootConfig) string { return formatUint(uint64(c.VnetPort)) },
+		func(c *BootConfig, v string) error {
+			n, err := strconv.ParseUint(v, 10, 16)
+			c.VnetPort = uint32(n)
+			return err
+		}},
+	{"dataStore", "PROBLER_DATASTORE", BootDb, "name of the targets/events data store",
+		func(c *BootConfig) string { return c.DataStore },
+		func(c *BootConfig, v string) error {
+			c.DataStore = v
+			return nil
+		}},
+	{"dataStoreBackend", "PROBLER_DATASTORE_BACKEND", BootDb, "data store backend: postgres, or memory to keep the data in the process, for services with a model only",
+		func(c *BootConfig) string { return c.DataStoreBackend },
+		func(c *BootConfig, v string) error {
+			v = strings.ToLower(v)
+			if v != BackendPostgres && v != BackendMemory {
+				return fmt.Errorf("unknown backend %q, expected postgres or memory", v)
+			}
+			c.DataStoreBackend = v
+			return nil
+		}},
+	{"timeSeriesStore", "PROBLER_TIMESERIES_STORE", BootDb, "name of the alarms time series store",
+		func(c *BootConfig) string { return c.TimeSeriesStore },
+		func(c *BootConfig, v string) error {
+			c.TimeSeriesStore = v
+			return nil
+		}},
+	{"txQueueSize", "PROBLER_TX_QUEUE_SIZE", "", "size of the outgoing message queue",
+		func(c *BootConfig) string { return formatUint(c.TxQueueSize) },
+		func(c *BootConfig, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			c.TxQueueSize = n
+			return err
+		}},
+	{"rxQueueSize", "PROBLER_RX_QUEUE_SIZE", "", "size of the incoming message queue",
+		func(c *BootConfig) string { return formatUint(c.RxQueueSize) },
+		func(c *BootConfig, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			c.RxQueueSize = n
+			return err
+		}},
+	{"shutdownSeconds", "PROBLER_SHUTDOWN_SECONDS", "", "seconds the shutdown hooks may take before the process exits",
+		func(c *BootConfig) string { return formatUint(uint64(c.ShutdownSeconds)) },
+		func(c *BootConfig, v string) error {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err == nil && n <= 0 {
+				err = errors.New("must be positive")
+			}
+			c.ShutdownSeconds = n
+			return err
+		}},
+	{"probePort", "PROBLER_PROBE_PORT", "", "port of the /healthz, /readyz and /metrics endpoints, 0 to disable",
+		func(c *BootConfig) string { return formatUint(uint64(c.ProbePort)) },
+		func(c *BootConfig, v string) error {
+			n, err := strconv.ParseUint(v, 10, 16)
+			c.ProbePort = uint32(n)
+			return err
+		}},
+	{"dbHost", "PROBLER_DB_HOST", BootDb, "host of an external Postgres, empty starts the local one",
+		func(c *BootConfig) string { return c.DbHost },
+		func(c *BootConfig, v string) error {
+			c.DbHost = v
+			return nil
+		}},
+	{"dbStartSeconds", "PROBLER_DB_START_SECONDS", BootDb, "seconds to wait for Postgres to accept connections",
+		func(c *BootConfig) string { return formatUint(uint64(c.DbStartSeconds)) },
+		func(c *BootConfig, v string) error {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err == nil && n <= 0 {
+				err = errors.New("must be positive")
+			}
+			c.DbStartSeconds = n
+			return err
+		}},
+	{"dbMigrations", "PROBLER_DB_MIGRATIONS", BootDb, "directory of the schema migrations, one sub directory per database",
+		func(c *BootConfig) string { return c.DbMigrations },
+		func(c *BootConfig, v string) error {
+			c.DbMigrations = v
+			return nil
+		}},
+	{"dbSslMode", "PROBLER_DB_SSLMODE", BootDb, "sslmode of the Postgres connections: disable, allow, prefer, require, verify-ca or verify-full",
+		func(c *BootConfig) string { return c.DbSslMode },
+		func(c *BootConfig, v string) error {
+			v = strings.ToLower(v)
+			if !dbSslModes[v] {
+				return fmt.Errorf("unknown sslmode %q, expected disable, allow, prefer, require, verify-ca or verify-full", v)
+			}
+			c.DbSslMode = v
+			return nil
+		}},
+	{"kubeconfig", "PROBLER_KUBECONFIG", BootAdcon, "kubeconfig files or directories of the clusters adcon collects, empty for its own cluster",
+		func(c *BootConfig) string { return c.Kubeconfig },
+		func(c *BootConfig, v string) error {
+			c.Kubeconfig = v
+			return nil
+		}},
+	{"kubeContexts", "PROBLER_KUBE_CONTEXTS", BootAdcon, "comma separated clusters of the kubeconfig to collect, empty for all",
+		func(c *BootConfig) string { return c.KubeContexts },
+		func(c *BootConfig, v string) error {
+			c.KubeContexts = v
+			return nil
+		}},
+	{"kubeDecommission", "PROBLER_KUBE_DECOMMISSION", BootAdcon, "comma separated clusters whose targets and summary adcon removes",
+		func(c *BootConfig) string { return c.KubeDecommission },
+		func(c *BootConfig, v string) error {
+			c.KubeDecommission = v
+			return nil
+		}},
+	{"kubeCollection", "PROBLER_KUBE_COLLECTION", BootAdcon, "YAML file selecting the kinds adcon collects per cluster, the namespaces its cluster summary counts and the poll intervals, e.g. a mounted ConfigMap",
+		func(c *BootConfig) string { return c.KubeCollection },
+		func(c *BootConfig, v string) error {
+			c.KubeCollection = v
+			return nil
+		}},
+	{"leaderLease", "PROBLER_LEADER_LEASE", BootAdcon, "[namespace/]name of the Lease adcon replicas elect the active one with, empty for no election",
+		func(c *BootConfig) string { return c.LeaderLease },
+		func(c *BootConfig, v string) error {
+			c.LeaderLease = v
+			return nil
+		}},
+}
+
+func formatUint(n uint64) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.FormatUint(n, 10)
+}
+
+// dbSslModes are the sslmode values lib/pq accepts.
+var dbSslModes = map[string]bool{
+	"disable": true, "allow": true, "prefer": true, "require": true, "verify-ca": true, "verify-full": true,
+}
+
+var logLevels = map[string]ifs.LogLevel{
+	"trace":   ifs.Trace_Level,
+	"debug":   ifs.Debug_Level,
+	"info":    ifs.Info_Level,
+	"warning": ifs.Warning_Level,
+	"error":   ifs.Error_Level,
+}
+
+// ParseLogLevel accepts trace, debug, info, warning (or warn) and error.
+func ParseLogLevel(name string) (ifs.LogLevel, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "warn" {
+		name = "warning"
+	}
+	if level, ok := logLevels[name]; ok {
+		return level, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q, expected trace, debug, info, warning or error", name)
+}
+
+// BootDefaults sets the defaults of one binary over the common ones, e.g. a
+// quieter log level. res has the system config of the security provider
+// loaded.
+type BootDefaults func(boot *BootConfig, res ifs.IResources)
+
+// bootDefaults starts from what the security provider loaded into the system
+// config, plus the common defaults and the ones of the binary.
+func bootDefaults(sysConfig *BootConfig, defaults BootDefaults, res ifs.IResources) *BootConfig {
+	cfg := *sysConfig
+	cfg.LogLevel = "info"
+	cfg.KeepAliveSeconds = 30
+	cfg.ShutdownSeconds = 30
+	cfg.DbStartSeconds = 120
+	cfg.DbMigrations = "/data/migrations"
+	cfg.DbSslMode = "disable"
+	if defaults != nil {
+		defaults(&cfg, res)
+	}
+	cfg.sources = make(map[string]string)
+	for _, s := range cfg.settings() {
+		if s.get(&cfg) != "" {
+			cfg.sources[s.key] = "default"
+		}
+	}
+	return &cfg
+}
+
+// LoadBootConfig layers the file, environment and flag settings over the
+// defaults. args are the process arguments without the program name; a
+// non flag argument ends the flags as with the flag package. The file may
+// hold the settings of groups the defaults do not parse, so one file serves
+// every binary; they are ignored.
+func LoadBootConfig(defaults *BootConfig, args []string) (*BootConfig, error) {
+	cfg := *defaults
+	cfg.sources = make(map[string]string)
+	for k, v := range defaults.sources {
+		cfg.sources[k] = v
+	}
+
+	fs := flag.NewFlagSet("probler", flag.ContinueOnError)
+	filename := fs.String("config", os.Getenv(BootConfigEnv), "YAML bootstrap file (env "+BootConfigEnv+")")
+	settings := cfg.settings()
+	values := make(map[string]*string)
+	for _, s := range settings {
+		values[s.key] = fs.String(flagName(s.key), "", s.usage+" (env "+s.env+")")
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *filename != "" {
+		data, err := os.ReadFile(*filename)
+		if err != nil {
+			return nil, err
+		}
+		file := &BootConfig{}
+		if err = yaml.UnmarshalStrict(data, file); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %s", *filename, err.Error())
+		}
+		for _, s := range settings {
+			if v := s.get(file); v != "" {
+				if err = s.set(&cfg, v); err != nil {
+					return nil, fmt.Errorf("%s: %s: %s", *filename, s.key, err.Error())
+				}
+				cfg.sources[s.key] = *filename
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if v := os.Getenv(s.env); v != "" {
+			if err := s.set(&cfg, v); err != nil {
+				return nil, fmt.Errorf("%s: %s", s.env, err.Error())
+			}
+			cfg.sources[s.key] = "env " + s.env
+		}
+	}
+
+	var err error
+	fs.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if f.Name == flagName(s.key) && err == nil {
+				if err = s.set(&cfg, *values[s.key]); err != nil {
+					err = fmt.Errorf("--%s: %s", f.Name, err.Error())
+				}
+				cfg.sources[s.key] = "flag --" + f.Name
+			}
+		}
+	})
+	return &cfg, err
+}
+
+// flagName turns keepAliveSeconds into keep-alive-seconds.
+func flagName(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteByte('-')
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Apply sets the log level and writes the settings into the system config.
+func (this *BootConfig) Apply(resources ifs.IResources) error {
+	level, err := ParseLogLevel(this.LogLevel)
+	if err != nil {
+		return err
+	}
+	resources.Logger().SetLogLevel(level)
+	setShutdownDeadline(time.Duration(this.ShutdownSeconds) * time.Second)
+	sc := resources.SysConfig()
+	sc.KeepAliveIntervalSeconds = this.KeepAliveSeconds
+	if this.VnetHost != "" {
+		sc.RemoteVnet = this.VnetHost
+	}
+	if this.VnetPort != 0 {
+		sc.VnetPort = this.VnetPort
+	}
+	if this.DataStore != "" {
+		if sc.DataStoreConfig == nil {
+			return errors.New("dataStore is set but the system config has no data store")
+		}
+		sc.DataStoreConfig.Name = this.DataStore
+	}
+	if this.TimeSeriesStore != "" {
+		if sc.TimeSeriesStoreConfig == nil {
+			return errors.New("timeSeriesStore is set but the system config has no time series store")
+		}
+		sc.TimeSeriesStoreConfig.Name = this.TimeSeriesStore
+	}
+	if this.TxQueueSize != 0 {
+		sc.TxQueueSize = this.TxQueueSize
+	}
+	if this.RxQueueSize != 0 {
+		sc.RxQueueSize = this.RxQueueSize
+	}
+	return nil
+}
+
+// String lists every setting with the layer it came from, one per line.
+func (this *BootConfig) String() string {
+	var b strings.Builder
+	for _, s := range this.settings() {
+		v := s.get(this)
+		if v == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "\n  %s: %s (%s)", s.key, v, this.sources[s.key])
+	}
+	return b.String()
+}
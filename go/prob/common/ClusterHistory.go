@@ -0,0 +1,244 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/saichler/l8types/go/ifs"
+	types3 "github.com/saichler/probler/go/types"
+)
+
+// ClusterMetric is one K8SClusterSummary counter kept as a time series.
+type ClusterMetric struct {
+	Name  string
+	value func(s *types3.K8SClusterSummary) int32
+}
+
+// ClusterMetrics are the summary counters recorded by the cluster history.
+var ClusterMetrics = []*ClusterMetric{
+	{"ready_nodes", func(s *types3.K8SClusterSummary) int32 { return s.ReadyNodes }},
+	{"total_nodes", func(s *types3.K8SClusterSummary) int32 { return s.TotalNodes }},
+	{"running_pods", func(s *types3.K8SClusterSummary) int32 { return s.RunningPods }},
+	{"pending_pods", func(s *types3.K8SClusterSummary) int32 { return s.PendingPods }},
+	{"failed_pods", func(s *types3.K8SClusterSummary) int32 { return s.FailedPods }},
+	{"total_pods", func(s *types3.K8SClusterSummary) int32 { return s.TotalPods }},
+	{"available_deployments", func(s *types3.K8SClusterSummary) int32 { return s.AvailableDeployments }},
+	{"total_deployments", func(s *types3.K8SClusterSummary) int32 { return s.TotalDeployments }},
+	{"ready_statefulsets", func(s *types3.K8SClusterSummary) int32 { return s.ReadyStatefulsets }},
+	{"ready_daemonsets", func(s *types3.K8SClusterSummary) int32 { return s.ReadyDaemonsets }},
+	{"active_jobs", func(s *types3.K8SClusterSummary) int32 { return s.ActiveJobs }},
+	{"bound_pvcs", func(s *types3.K8SClusterSummary) int32 { return s.BoundPvcs }},
+	{"warning_events", func(s *types3.K8SClusterSummary) int32 { return s.WarningEvents }},
+}
+
+// HistoryTier is one resolution of the cluster history. The points of the
+// previous tier are averaged into Step buckets, keeping their min and max so
+// a short dip survives, and kept for Keep. The first tier has Step 0 and
+// holds the points as recorded.
+type HistoryTier struct {
+	Step time.Duration
+	Keep time.Duration
+}
+
+// ClusterHistoryTiers is the retention of the cluster history, finest first.
+var ClusterHistoryTiers = []*HistoryTier{
+	{Step: 0, Keep: 48 * time.Hour},
+	{Step: 5 * time.Minute, Keep: 14 * 24 * time.Hour},
+	{Step: time.Hour, Keep: 400 * 24 * time.Hour},
+}
+
+// clusterHistoryCompaction is how often the tiers are rolled up and trimmed.
+const clusterHistoryCompaction = 5 * time.Minute
+
+// ClusterHistory stores K8SClusterSummary snapshots as time series in the
+// time series store, which must be an external Postgres (dbHost), and serves
+// them through the cluster history service.
+type ClusterHistory struct {
+	db        *sql.DB
+	resources ifs.IResources
+}
+
+// OpenClusterHistory connects to the time series store, creates the history
+// table, starts the roll up and retention and activates the cluster history
+// service on nic.
+func OpenClusterHistory(nic ifs.IVNic) (*ClusterHistory, error) {
+	resources := nic.Resources()
+	if effectiveBoot.DbHost == "" {
+		return nil, errors.New("cluster history needs the external time series store, dbHost is not set")
+	}
+	sc := resources.SysConfig().TimeSeriesStoreConfig
+	if sc == nil {
+		return nil, errors.New("cluster history needs a time series store config")
+	}
+	pg, err := NewPostgres(sc.Type, sc.Name, resources)
+	if err != nil {
+		return nil, err
+	}
+	if err = pg.Start(); err != nil {
+		return nil, err
+	}
+	_, err = pg.db.Exec(`create table if not exists probler_cluster_history (
+		cluster text not null,
+		metric text not null,
+		step integer not null,
+		stamp bigint not null,
+		value double precision not null,
+		min_value double precision not null,
+		max_value double precision not null,
+		primary key (cluster, step, metric, stamp))`)
+	if err != nil {
+		return nil, err
+	}
+	this := &ClusterHistory{db: pg.db, resources: resources}
+
+	stop := make(chan struct{})
+	OnShutdown(ShutdownIntake, "cluster history", func(ctx context.Context) error {
+		close(stop)
+		return nil
+	})
+	go this.compactLoop(stop)
+	sla := ifs.NewServiceLevelAgreement(&ClusterHistoryService{history: this},
+		ClusterHistory_Service_Name, ClusterHistory_Service_Area, false, nil)
+	if _, err = resources.Services().Activate(sla, nic); err != nil {
+		return nil, err
+	}
+	return this, nil
+}
+
+// Record stores a snapshot of a cluster summary taken at stamp.
+func (this *ClusterHistory) Record(cluster string, stamp time.Time, summary *types3.K8SClusterSummary) error {
+	tx, err := this.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, m := range ClusterMetrics {
+		v := float64(m.value(summary))
+		_, err = tx.Exec(`insert into probler_cluster_history
+			(cluster, metric, step, stamp, value, min_value, max_value) values ($1, $2, 0, $3, $4, $4, $4)
+			on conflict (cluster, step, metric, stamp) do update
+			set value = excluded.value, min_value = excluded.min_value, max_value = excluded.max_value`,
+			cluster, m.Name, stamp.Unix(), v)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (this *ClusterHistory) compactLoop(stop chan struct{}) {
+	ticker := time.NewTicker(clusterHistoryCompaction)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		if err := this.Compact(time.Now()); err != nil {
+			this.resources.Logger().Error("Cluster history compaction: ", err.Error())
+		}
+	}
+}
+
+// Compact rolls the complete buckets of every tier up into the next one and
+// drops the points that are past their tier's retention. Each cluster resumes
+// after its own last rolled up bucket, so a cluster that was added, or did not
+// record for a while, is not skipped past by the others. It is idempotent, so
+// several adcon instances may compact the same store.
+func (this *ClusterHistory) Compact(now time.Time) error {
+	for i := 1; i < len(ClusterHistoryTiers); i++ {
+		finer := ClusterHistoryTiers[i-1]
+		step := int64(ClusterHistoryTiers[i].Step.Seconds())
+		end := now.Unix() / step * step
+		// Nothing older than the oldest point the finer tier still keeps.
+		start := now.Add(-finer.Keep).Unix() / step * step
+		_, err := this.db.Exec(`insert into probler_cluster_history
+			(cluster, metric, step, stamp, value, min_value, max_value)
+			select f.cluster, f.metric, $1::bigint, f.stamp / $1::bigint * $1::bigint,
+				avg(f.value), min(f.min_value), max(f.max_value)
+			from probler_cluster_history f
+			where f.step = $2::bigint and f.stamp >= $3::bigint and f.stamp < $4::bigint
+			and f.stamp >= coalesce((select max(r.stamp) + $1::bigint from probler_cluster_history r
+				where r.step = $1::bigint and r.cluster = f.cluster), 0)
+			group by f.cluster, f.metric, f.stamp / $1::bigint * $1::bigint
+			on conflict (cluster, step, metric, stamp) do update
+			set value = excluded.value, min_value = excluded.min_value, max_value = excluded.max_value`,
+			step, int64(finer.Step.Seconds()), start, end)
+		if err != nil {
+			return err
+		}
+	}
+	for _, tier := range ClusterHistoryTiers {
+		_, err := this.db.Exec("delete from probler_cluster_history where step = $1 and stamp < $2",
+			int64(tier.Step.Seconds()), now.Add(-tier.Keep).Unix())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClusterHistoryTier is the finest tier that still keeps the points at from,
+// the coarsest one when none does.
+func ClusterHistoryTier(from, now time.Time) *HistoryTier {
+	for _, t := range ClusterHistoryTiers {
+		if !from.Before(now.Add(-t.Keep)) {
+			return t
+		}
+	}
+	return ClusterHistoryTiers[len(ClusterHistoryTiers)-1]
+}
+
+// Query returns the series of a cluster between from and to. No metrics
+// means all of them.
+func (this *ClusterHistory) Query(cluster string, from, to time.Time, metrics ...string) (*types3.ClusterSeries, error) {
+	step := int64(ClusterHistoryTier(from, time.Now()).Step.Seconds())
+	rows, err := this.db.Query(`select metric, stamp, value, min_value, max_value from probler_cluster_history
+		where cluster = $1 and step = $2 and stamp >= $3 and stamp <= $4 order by metric, stamp`,
+		cluster, step, from.Unix(), to.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	wanted := make(map[string]bool)
+	for _, m := range metrics {
+		wanted[m] = true
+	}
+	series := &types3.ClusterSeries{Cluster: cluster, StepSeconds: step, Metrics: make(map[string]*types3.SeriesPoints)}
+	for rows.Next() {
+		var metric string
+		p := &types3.SeriesPoint{}
+		if err = rows.Scan(&metric, &p.Stamp, &p.Value, &p.Min, &p.Max); err != nil {
+			return nil, err
+		}
+		if len(wanted) > 0 && !wanted[metric] {
+			continue
+		}
+		points, ok := series.Metrics[metric]
+		if !ok {
+			points = &types3.SeriesPoints{}
+			series.Metrics[metric] = points
+		}
+		points.Points = append(points.Points, p)
+	}
+	return series, rows.Err()
+}
@@ -0,0 +1,114 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8utils/go/utils/web"
+	types3 "github.com/saichler/probler/go/types"
+)
+
+const (
+	ClusterHistory_Service_Name = "ClsHist"
+	ClusterHistory_Service_Area = byte(0)
+)
+
+// ClusterHistoryService answers a ClusterHistoryQuery with the series of the
+// cluster, so the history is read over the bus and the web server like any
+// other service. A query is a POST, as the range and metrics do not fit a
+// where clause on ClusterSeries.
+type ClusterHistoryService struct {
+	serviceName string
+	serviceArea byte
+	history     *ClusterHistory
+}
+
+// RegisterClusterHistoryTypes registers the cluster history service types, on
+// the service side and on the web server.
+func RegisterClusterHistoryTypes(res ifs.IResources) {
+	res.Registry().Register(&types3.ClusterHistoryQuery{})
+	res.Registry().Register(&types3.ClusterSeries{})
+}
+
+func (this *ClusterHistoryService) Activate(sla *ifs.ServiceLevelAgreement, vnic ifs.IVNic) error {
+	this.serviceName = sla.ServiceName()
+	this.serviceArea = sla.ServiceArea()
+	RegisterClusterHistoryTypes(vnic.Resources())
+	return nil
+}
+
+func (this *ClusterHistoryService) DeActivate() error {
+	return nil
+}
+
+// Post runs the query, the last hour up to now when it has no range.
+func (this *ClusterHistoryService) Post(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	q, ok := pb.Element().(*types3.ClusterHistoryQuery)
+	if !ok {
+		return object.New(fmt.Errorf("unexpected request %T", pb.Element()), &types3.ClusterSeries{})
+	}
+	if q.Cluster == "" {
+		return object.New(errors.New("cluster is required"), &types3.ClusterSeries{})
+	}
+	to := time.Now()
+	if q.To != 0 {
+		to = time.Unix(q.To, 0)
+	}
+	from := to.Add(-time.Hour)
+	if q.From != 0 {
+		from = time.Unix(q.From, 0)
+	}
+	series, err := this.history.Query(q.Cluster, from, to, q.Metrics...)
+	if err != nil {
+		return object.New(err, &types3.ClusterSeries{})
+	}
+	return object.New(nil, series)
+}
+
+func (this *ClusterHistoryService) Put(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return readOnly(this.serviceName)
+}
+
+func (this *ClusterHistoryService) Patch(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return readOnly(this.serviceName)
+}
+
+func (this *ClusterHistoryService) Delete(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return readOnly(this.serviceName)
+}
+
+func (this *ClusterHistoryService) Get(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return object.New(fmt.Errorf("%s is queried with a POST of a ClusterHistoryQuery", this.serviceName), nil)
+}
+
+func (this *ClusterHistoryService) Failed(pb ifs.IElements, vnic ifs.IVNic, msg *ifs.Message) ifs.IElements {
+	return nil
+}
+
+func (this *ClusterHistoryService) TransactionConfig() ifs.ITransactionConfig {
+	return nil
+}
+
+func (this *ClusterHistoryService) WebService() ifs.IWebService {
+	ws := web.New(this.serviceName, this.serviceArea, 0)
+	ws.AddEndpoint(&types3.ClusterHistoryQuery{}, ifs.POST, &types3.ClusterSeries{})
+	return ws
+}
@@ -0,0 +1,99 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/saichler/l8types/go/ifs"
+	"google.golang.org/protobuf/proto"
+)
+
+// Data store backends, see the dataStoreBackend boot setting.
+const (
+	BackendPostgres = "postgres"
+	BackendMemory   = "memory"
+)
+
+// StoreService is a service that persists one model to a data store.
+type StoreService struct {
+	Name        string
+	Area        byte
+	Model       proto.Message
+	List        proto.Message
+	PrimaryKeys []string
+	// Activate activates the service on a Postgres store of the given type
+	// and name, as its library does.
+	Activate func(storeType, storeName string, nic ifs.IVNic)
+}
+
+// DataStore is the database the services of a binary persist to.
+type DataStore interface {
+	// Start brings the store up, it fails if the store is not usable.
+	Start() error
+	// ReportHealth reports the store going down and coming back to the health
+	// service.
+	ReportHealth(nic ifs.IVNic)
+	// Activate activates a service on the store.
+	Activate(service *StoreService, nic ifs.IVNic) error
+}
+
+// NewDataStore returns the store of the given type and name, e.g. the
+// DataStoreConfig of the system config, on the backend of the boot config.
+func NewDataStore(storeType, storeName string, resources ifs.IResources) (DataStore, error) {
+	switch effectiveBoot.DataStoreBackend {
+	case BackendMemory:
+		return &memoryStore{storeName: storeName, resources: resources}, nil
+	case "", BackendPostgres:
+		return NewPostgres(storeType, storeName, resources)
+	}
+	return nil, fmt.Errorf("unknown data store backend %q", effectiveBoot.DataStoreBackend)
+}
+
+// Activate activates the service with its library on this database.
+func (this *Postgres) Activate(service *StoreService, nic ifs.IVNic) error {
+	service.Activate(this.storeType, this.storeName, nic)
+	return nil
+}
+
+// memoryStore keeps each service's elements in the process with a
+// MemoryService. Services without a model, whose library needs Postgres, fail
+// to activate.
+type memoryStore struct {
+	storeName string
+	resources ifs.IResources
+}
+
+func (this *memoryStore) Start() error {
+	this.resources.Logger().Info("Data store ", this.storeName, " is in memory, its data is lost on exit")
+	return nil
+}
+
+func (this *memoryStore) ReportHealth(nic ifs.IVNic) {
+}
+
+func (this *memoryStore) Activate(service *StoreService, nic ifs.IVNic) error {
+	if service.Model == nil {
+		return fmt.Errorf("%s needs the postgres data store", service.Name)
+	}
+	handler, err := NewMemoryService(service.Model, service.List, service.PrimaryKeys...)
+	if err != nil {
+		return fmt.Errorf("%s: %s", service.Name, err.Error())
+	}
+	sla := ifs.NewServiceLevelAgreement(handler, service.Name, service.Area, false, nil)
+	nic.Resources().Services().Activate(sla, nic)
+	return nil
+}
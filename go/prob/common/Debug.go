@@ -0,0 +1,141 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/saichler/l8types/go/ifs"
+)
+
+// The state behind the debug controls of a process, see DebugService. A
+// component is the alias of one resources of the process, e.g. parser or box
+// in the all in one binary; no component means all of them.
+var debug = struct {
+	mtx        sync.RWMutex
+	components map[string]ifs.IResources
+	tracer     ifs.IResources
+	traced     map[string]bool
+	tracing    atomic.Int32
+}{components: make(map[string]ifs.IResources), traced: make(map[string]bool)}
+
+// registerComponent adds the resources of an alias. The first one, the one
+// CreateResources built, logs the routing trace.
+func registerComponent(alias string, resources ifs.IResources) {
+	debug.mtx.Lock()
+	defer debug.mtx.Unlock()
+	debug.components[alias] = resources
+	if debug.tracer == nil {
+		debug.tracer = resources
+	}
+}
+
+// SetLogLevel changes the log level of one component, or of every component
+// when component is empty, and returns the components it changed.
+func SetLogLevel(component, level string) ([]string, error) {
+	lvl, err := ParseLogLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	debug.mtx.RLock()
+	defer debug.mtx.RUnlock()
+	changed := make([]string, 0)
+	for alias, res := range debug.components {
+		if component == "" || alias == component {
+			res.Logger().SetLogLevel(lvl)
+			changed = append(changed, alias)
+		}
+	}
+	if len(changed) == 0 {
+		return nil, fmt.Errorf("no component %q", component)
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// TraceLinks turns the routing trace of a links id on or off. While on, every
+// collector, parser, cache and persist lookup of its CJobs is logged.
+func TraceLinks(linkid string, on bool) error {
+	if (&Links{}).Model(linkid) == "" {
+		return fmt.Errorf("unknown links id %q", linkid)
+	}
+	debug.mtx.Lock()
+	defer debug.mtx.Unlock()
+	if on {
+		debug.traced[linkid] = true
+	} else {
+		delete(debug.traced, linkid)
+	}
+	debug.tracing.Store(int32(len(debug.traced)))
+	return nil
+}
+
+func tracedLinks() []string {
+	debug.mtx.RLock()
+	defer debug.mtx.RUnlock()
+	ids := make([]string, 0, len(debug.traced))
+	for id := range debug.traced {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func traceRoute(linkid, role, name string, area byte) {
+	if debug.tracing.Load() == 0 {
+		return
+	}
+	debug.mtx.RLock()
+	defer debug.mtx.RUnlock()
+	if !debug.traced[linkid] || debug.tracer == nil {
+		return
+	}
+	debug.tracer.Logger().Info("trace ", linkid, " ", role, " -> ", name, "/", strconv.Itoa(int(area)))
+}
+
+// tracingLinks is the Links installed as targets.Links, it reports every
+// route lookup of a traced links id.
+type tracingLinks struct {
+	*Links
+}
+
+func (this *tracingLinks) Collector(linkid string) (string, byte) {
+	name, area := this.Links.Collector(linkid)
+	traceRoute(linkid, "collector", name, area)
+	return name, area
+}
+
+func (this *tracingLinks) Parser(linkid string) (string, byte) {
+	name, area := this.Links.Parser(linkid)
+	traceRoute(linkid, "parser", name, area)
+	return name, area
+}
+
+func (this *tracingLinks) Cache(linkid string) (string, byte) {
+	name, area := this.Links.Cache(linkid)
+	traceRoute(linkid, "cache", name, area)
+	return name, area
+}
+
+func (this *tracingLinks) Persist(linkid string) (string, byte) {
+	name, area := this.Links.Persist(linkid)
+	traceRoute(linkid, "persist", name, area)
+	return name, area
+}
@@ -0,0 +1,221 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"runtime/pprof"
+	"sort"
+	"strings"
+
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8health"
+	"github.com/saichler/l8utils/go/utils/web"
+	types3 "github.com/saichler/probler/go/types"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	Debug_Service_Name = "Debug"
+	Debug_Service_Area = byte(0)
+)
+
+// debugRequestTimeout is how many seconds a forwarded control waits for the
+// process it is addressed to, a heap profile takes a while.
+const debugRequestTimeout = 30
+
+// DebugService runs on every vnic and takes the debug controls of the process
+// by POST. A control for another alias is forwarded to the vnic the health
+// service reports for it, one with no alias to every process serving it, so
+// prctl and the web UI reach any process through whichever one answers.
+type DebugService struct {
+	serviceName string
+	serviceArea byte
+}
+
+// RegisterDebugTypes registers the debug service types, on the service side
+// and on the web server.
+func RegisterDebugTypes(res ifs.IResources) {
+	res.Registry().Register(&types3.DebugControl{})
+	res.Registry().Register(&types3.DebugReply{})
+	res.Registry().Register(&types3.DebugReplyList{})
+	res.Registry().RegisterEnums(types3.DebugAction_value)
+}
+
+func (this *DebugService) Activate(sla *ifs.ServiceLevelAgreement, vnic ifs.IVNic) error {
+	this.serviceName = sla.ServiceName()
+	this.serviceArea = sla.ServiceArea()
+	RegisterDebugTypes(vnic.Resources())
+	return nil
+}
+
+func (this *DebugService) DeActivate() error {
+	return nil
+}
+
+// Post applies a control, or forwards it, and returns one reply per process.
+func (this *DebugService) Post(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	ctl, ok := pb.Element().(*types3.DebugControl)
+	if !ok {
+		return object.New(fmt.Errorf("%s takes a DebugControl, got %T", this.serviceName, pb.Element()), nil)
+	}
+	local := vnic.Resources().SysConfig().LocalAlias
+	if ctl.Forwarded && ctl.Alias != local {
+		return object.New(fmt.Errorf("control for %s reached %s", ctl.Alias, local), nil)
+	}
+	if ctl.Alias == local {
+		return object.New(nil, &types3.DebugReplyList{List: []*types3.DebugReply{ApplyDebug(local, ctl)}})
+	}
+	top, err := healthTop(vnic)
+	if err != nil {
+		return object.New(err, nil)
+	}
+	hosts := DebugHosts(top, ctl.Alias)
+	if len(hosts) == 0 {
+		return object.New(fmt.Errorf("no process %q serves %s", ctl.Alias, this.serviceName), nil)
+	}
+	list := &types3.DebugReplyList{List: make([]*types3.DebugReply, 0, len(hosts))}
+	for _, hp := range hosts {
+		if hp.Alias == local {
+			list.List = append(list.List, ApplyDebug(local, ctl))
+			continue
+		}
+		list.List = append(list.List, this.forward(vnic, hp, ctl))
+	}
+	return object.New(nil, list)
+}
+
+func (this *DebugService) Put(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return postOnly(this.serviceName)
+}
+
+func (this *DebugService) Patch(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return postOnly(this.serviceName)
+}
+
+func (this *DebugService) Delete(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return postOnly(this.serviceName)
+}
+
+func (this *DebugService) Get(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return postOnly(this.serviceName)
+}
+
+func (this *DebugService) Failed(pb ifs.IElements, vnic ifs.IVNic, msg *ifs.Message) ifs.IElements {
+	return nil
+}
+
+func (this *DebugService) TransactionConfig() ifs.ITransactionConfig {
+	return nil
+}
+
+func (this *DebugService) WebService() ifs.IWebService {
+	ws := web.New(this.serviceName, this.serviceArea, 0)
+	ws.AddEndpoint(&types3.DebugControl{}, ifs.POST, &types3.DebugReplyList{})
+	return ws
+}
+
+// forward sends a control to the debug service of one process and returns its
+// reply, or the failure as the reply of that process.
+func (this *DebugService) forward(vnic ifs.IVNic, hp *l8health.L8Health, ctl *types3.DebugControl) *types3.DebugReply {
+	fwd := proto.Clone(ctl).(*types3.DebugControl)
+	fwd.Alias = hp.Alias
+	fwd.Forwarded = true
+	resp := vnic.Request(hp.AUuid, this.serviceName, this.serviceArea, ifs.POST, fwd, debugRequestTimeout)
+	if resp == nil {
+		return &types3.DebugReply{Alias: hp.Alias, Error: "no answer"}
+	}
+	if resp.Error() != nil {
+		return &types3.DebugReply{Alias: hp.Alias, Error: resp.Error().Error()}
+	}
+	list, ok := resp.Element().(*types3.DebugReplyList)
+	if !ok || len(list.List) != 1 {
+		return &types3.DebugReply{Alias: hp.Alias, Error: fmt.Sprintf("unexpected response %T", resp.Element())}
+	}
+	return list.List[0]
+}
+
+func postOnly(serviceName string) ifs.IElements {
+	return object.New(fmt.Errorf("%s only takes controls by POST", serviceName), nil)
+}
+
+// DebugHosts returns the processes of a health snapshot serving the debug
+// service in alias order, only the one of alias unless it is empty.
+func DebugHosts(top *l8health.L8Top, alias string) []*l8health.L8Health {
+	hosts := make([]*l8health.L8Health, 0)
+	if top == nil {
+		return hosts
+	}
+	for _, hp := range top.Healths {
+		if alias != "" && hp.Alias != alias {
+			continue
+		}
+		if hp.Services == nil || hp.Services.ServiceToAreas == nil {
+			continue
+		}
+		if areas, ok := hp.Services.ServiceToAreas[Debug_Service_Name]; ok && areas.Areas[int32(Debug_Service_Area)] {
+			hosts = append(hosts, hp)
+		}
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].Alias < hosts[j].Alias
+	})
+	return hosts
+}
+
+// ApplyDebug runs a control in this process, alias names it in the reply.
+func ApplyDebug(alias string, ctl *types3.DebugControl) *types3.DebugReply {
+	reply := &types3.DebugReply{Alias: alias}
+	var err error
+	switch ctl.Action {
+	case types3.DebugAction_DEBUG_ACTION_LOG_LEVEL:
+		var changed []string
+		if changed, err = SetLogLevel(ctl.Component, ctl.Level); err == nil {
+			reply.Message = "log level " + ctl.Level + " set on " + strings.Join(changed, ", ")
+		}
+	case types3.DebugAction_DEBUG_ACTION_TRACE, types3.DebugAction_DEBUG_ACTION_UNTRACE:
+		if ctl.LinksId != "" {
+			err = TraceLinks(ctl.LinksId, ctl.Action == types3.DebugAction_DEBUG_ACTION_TRACE)
+		} else if ctl.Action == types3.DebugAction_DEBUG_ACTION_UNTRACE {
+			err = fmt.Errorf("no links id to stop tracing")
+		}
+		if err == nil {
+			reply.Message = "tracing: " + strings.Join(tracedLinks(), ", ")
+		}
+	case types3.DebugAction_DEBUG_ACTION_DUMP_GOROUTINES:
+		reply.Dump, err = debugDump("goroutine", 2)
+	case types3.DebugAction_DEBUG_ACTION_DUMP_HEAP:
+		reply.Dump, err = debugDump("heap", 0)
+	default:
+		err = fmt.Errorf("unknown debug action %s", ctl.Action.String())
+	}
+	if err != nil {
+		reply.Error = err.Error()
+	}
+	return reply
+}
+
+// debugDump writes a runtime profile, format 2 is the goroutine dump of a
+// panic and 0 the pprof format.
+func debugDump(profile string, format int) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := pprof.Lookup(profile).WriteTo(buf, format); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
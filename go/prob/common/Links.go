@@ -50,6 +50,9 @@ const (
 type Links struct{}
 
 func (this *Links) Collector(linkid string) (string, byte) {
+	if e, ok := runtimeLink(linkid); ok {
+		return e.CollectorName, e.CollectorArea
+	}
 	if linkid == K8sC_Links_ID {
 		return AdControl_Service_Name, AdControl_Service_Area
 	}
@@ -70,6 +73,9 @@ func (this *Links) Parser(linkid string) (string, byte) {
 	case GPU_Links_ID:
 		return GPU_Parser_Service_Name, GPU_Parser_Service_Area
 	}
+	if e, ok := runtimeLink(linkid); ok {
+		return e.ParserName, e.ParserArea
+	}
 	return "", 0
 }
 
@@ -87,6 +93,9 @@ func (this *Links) Cache(linkid string) (string, byte) {
 	case GPU_Links_ID:
 		return GPU_Cache_Service_Name, GPU_Cache_Service_Area
 	}
+	if e, ok := runtimeLink(linkid); ok {
+		return e.CacheName, e.CacheArea
+	}
 	return "", 0
 }
 
@@ -104,6 +113,9 @@ func (this *Links) Persist(linkid string) (string, byte) {
 	case GPU_Links_ID:
 		return GPU_Persist_Service_Name, GPU_Persist_Service_Area
 	}
+	if e, ok := runtimeLink(linkid); ok {
+		return e.PersistName, e.PersistArea
+	}
 	return "", 0
 }
 
@@ -121,5 +133,8 @@ func (this *Links) Model(linkid string) string {
 	case GPU_Links_ID:
 		return GPU_Model_Name
 	}
+	if e, ok := runtimeLink(linkid); ok {
+		return e.Model
+	}
 	return ""
 }
@@ -0,0 +1,140 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+// LinksFileEnv names the file with the runtime links entries, see LoadLinksFile.
+const LinksFileEnv = "PROBLER_LINKS"
+
+// LinkEntry routes a links id that is not compiled into the Links tables, so a
+// new device family only needs its parser/inventory pair deployed together
+// with an entry like:
+//
+//   - linksId: PDU
+//     parserName: PPars
+//     parserArea: 3
+//     cacheName: PCache
+//     cacheArea: 3
+//     persistName: PPersist
+//     persistArea: 3
+//     model: pdudevice
+//
+// An empty collector defaults to the shared collector service.
+type LinkEntry struct {
+	LinksId       string `json:"linksId"`
+	CollectorName string `json:"collectorName,omitempty"`
+	CollectorArea byte   `json:"collectorArea,omitempty"`
+	ParserName    string `json:"parserName"`
+	ParserArea    byte   `json:"parserArea"`
+	CacheName     string `json:"cacheName"`
+	CacheArea     byte   `json:"cacheArea"`
+	PersistName   string `json:"persistName"`
+	PersistArea   byte   `json:"persistArea"`
+	Model         string `json:"model"`
+}
+
+var runtimeLinks = struct {
+	mtx     sync.RWMutex
+	entries map[string]*LinkEntry
+}{entries: make(map[string]*LinkEntry)}
+
+// RegisterLink adds a runtime links entry. Compiled links ids cannot be
+// overridden, and re-registering a runtime id replaces its entry. An entry
+// whose services collide with existing routes is rejected.
+func RegisterLink(entry *LinkEntry) error {
+	if entry == nil || entry.LinksId == "" {
+		return errors.New("links entry has no linksId")
+	}
+	if compiledLink(entry.LinksId) {
+		return fmt.Errorf("links id %s is compiled in and cannot be redefined", entry.LinksId)
+	}
+	if entry.ParserName == "" || entry.CacheName == "" || entry.PersistName == "" || entry.Model == "" {
+		return fmt.Errorf("links id %s: parser, cache, persist and model are required", entry.LinksId)
+	}
+	e := *entry
+	if e.CollectorName == "" {
+		e.CollectorName, e.CollectorArea = Collector_Service_Name, Collector_Service_Area
+	}
+	runtimeLinks.mtx.Lock()
+	previous, replaced := runtimeLinks.entries[e.LinksId]
+	runtimeLinks.entries[e.LinksId] = &e
+	runtimeLinks.mtx.Unlock()
+
+	if err := linksError(validateRoutes()); err != nil {
+		runtimeLinks.mtx.Lock()
+		if replaced {
+			runtimeLinks.entries[e.LinksId] = previous
+		} else {
+			delete(runtimeLinks.entries, e.LinksId)
+		}
+		runtimeLinks.mtx.Unlock()
+		return err
+	}
+	return nil
+}
+
+// LoadLinksFile registers every entry of a YAML or JSON list of LinkEntry.
+func LoadLinksFile(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	entries := make([]*LinkEntry, 0)
+	if err = yaml.UnmarshalStrict(data, &entries); err != nil {
+		return fmt.Errorf("failed to parse %s: %s", filename, err.Error())
+	}
+	for _, entry := range entries {
+		if err = RegisterLink(entry); err != nil {
+			return fmt.Errorf("%s: %s", filename, err.Error())
+		}
+	}
+	return nil
+}
+
+func runtimeLink(linkid string) (*LinkEntry, bool) {
+	runtimeLinks.mtx.RLock()
+	defer runtimeLinks.mtx.RUnlock()
+	entry, ok := runtimeLinks.entries[linkid]
+	return entry, ok
+}
+
+// runtimeLinksIds returns the registered runtime links ids in order.
+func runtimeLinksIds() []string {
+	runtimeLinks.mtx.RLock()
+	defer runtimeLinks.mtx.RUnlock()
+	ids := make([]string, 0, len(runtimeLinks.entries))
+	for id := range runtimeLinks.entries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func compiledLink(linkid string) bool {
+	if _, ok := k8sLinkMap[linkid]; ok {
+		return true
+	}
+	return linkid == NetworkDevice_Links_ID || linkid == GPU_Links_ID || linkid == K8sC_Links_ID
+}
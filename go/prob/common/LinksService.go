@@ -0,0 +1,201 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/saichler/l8bus/go/overlay/health"
+	"github.com/saichler/l8pollaris/go/pollaris/targets"
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8api"
+	"github.com/saichler/l8types/go/types/l8health"
+	"github.com/saichler/l8utils/go/utils/web"
+	types3 "github.com/saichler/probler/go/types"
+)
+
+const (
+	Links_Service_Name = "Links"
+	Links_Service_Area = byte(0)
+)
+
+// Links roles, in pipeline order.
+const (
+	LinkCollector = "collector"
+	LinkParser    = "parser"
+	LinkCache     = "cache"
+	LinkPersist   = "persist"
+)
+
+// LinksService answers a LinkRoute query with the Links tables joined with the
+// health snapshot of the vnic it runs on, so clients get the routes of every
+// links id without pulling the whole health table.
+type LinksService struct {
+	serviceName string
+	serviceArea byte
+}
+
+// RegisterLinksTypes registers the links service types, on the service side and
+// on the web server.
+func RegisterLinksTypes(res ifs.IResources) {
+	res.Introspector().Decorators().AddPrimaryKeyDecorator(&types3.LinkRoute{}, "LinksId")
+	res.Registry().Register(&types3.LinkRoute{})
+	res.Registry().Register(&types3.LinkRouteList{})
+}
+
+func (this *LinksService) Activate(sla *ifs.ServiceLevelAgreement, vnic ifs.IVNic) error {
+	this.serviceName = sla.ServiceName()
+	this.serviceArea = sla.ServiceArea()
+	RegisterLinksTypes(vnic.Resources())
+	return nil
+}
+
+func (this *LinksService) DeActivate() error {
+	return nil
+}
+
+func (this *LinksService) Post(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return readOnly(this.serviceName)
+}
+
+func (this *LinksService) Put(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return readOnly(this.serviceName)
+}
+
+func (this *LinksService) Patch(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return readOnly(this.serviceName)
+}
+
+func (this *LinksService) Delete(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return readOnly(this.serviceName)
+}
+
+// Get returns the routes matching the query, every links id when it has no
+// where clause.
+func (this *LinksService) Get(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	query, err := pb.Query(vnic.Resources())
+	if err != nil {
+		return object.New(err, &types3.LinkRouteList{})
+	}
+	top, err := healthTop(vnic)
+	if err != nil {
+		return object.New(err, &types3.LinkRouteList{})
+	}
+	routes, err := LinksTopology(top, nil)
+	if err != nil {
+		return object.New(err, &types3.LinkRouteList{})
+	}
+	list := &types3.LinkRouteList{List: make([]*types3.LinkRoute, 0, len(routes))}
+	for _, route := range routes {
+		if query == nil || query.Match(route) {
+			list.List = append(list.List, route)
+		}
+	}
+	return object.New(nil, list)
+}
+
+func (this *LinksService) Failed(pb ifs.IElements, vnic ifs.IVNic, msg *ifs.Message) ifs.IElements {
+	return nil
+}
+
+func (this *LinksService) TransactionConfig() ifs.ITransactionConfig {
+	return nil
+}
+
+func (this *LinksService) WebService() ifs.IWebService {
+	ws := web.New(this.serviceName, this.serviceArea, 0)
+	ws.AddEndpoint(&l8api.L8Query{}, ifs.GET, &types3.LinkRouteList{})
+	return ws
+}
+
+func readOnly(serviceName string) ifs.IElements {
+	return object.New(fmt.Errorf("%s is read only", serviceName), nil)
+}
+
+// healthTop reads the health snapshot from the local replica of the health
+// service, every vnic holds one.
+func healthTop(vnic ifs.IVNic) (*l8health.L8Top, error) {
+	hs, ok := vnic.Resources().Services().ServiceHandler(health.ServiceName, 0)
+	if !ok {
+		return nil, errors.New("health service is not activated")
+	}
+	resp := hs.Get(object.New(nil, &l8health.L8Health{}), vnic)
+	if resp == nil {
+		return nil, errors.New("no answer from the health service")
+	}
+	if resp.Error() != nil {
+		return nil, resp.Error()
+	}
+	top, ok := resp.Element().(*l8health.L8Top)
+	if !ok {
+		return nil, fmt.Errorf("unexpected health response %T", resp.Element())
+	}
+	return top, nil
+}
+
+// LinksTopology joins the Links tables with a health snapshot. A process hosts
+// a role when it registered the role's service name in the role's area. No ids
+// means every links id.
+func LinksTopology(top *l8health.L8Top, linkids []string) ([]*types3.LinkRoute, error) {
+	if len(linkids) == 0 {
+		linkids = LinksIds()
+	}
+	links := targets.Links
+	routes := make([]*types3.LinkRoute, 0, len(linkids))
+	for _, linkid := range linkids {
+		model := links.Model(linkid)
+		if model == "" {
+			return nil, fmt.Errorf("unknown links id %q", linkid)
+		}
+		route := &types3.LinkRoute{LinksId: linkid, Model: model}
+		name, area := links.Collector(linkid)
+		route.Roles = append(route.Roles, linkRole(top, LinkCollector, name, area))
+		name, area = links.Parser(linkid)
+		route.Roles = append(route.Roles, linkRole(top, LinkParser, name, area))
+		name, area = links.Cache(linkid)
+		route.Roles = append(route.Roles, linkRole(top, LinkCache, name, area))
+		name, area = links.Persist(linkid)
+		route.Roles = append(route.Roles, linkRole(top, LinkPersist, name, area))
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+func linkRole(top *l8health.L8Top, role, name string, area byte) *types3.LinkRole {
+	lr := &types3.LinkRole{Role: role, Service: name, Area: int32(area), Hosts: make([]string, 0)}
+	if top == nil {
+		return lr
+	}
+	for _, hp := range top.Healths {
+		if hp.Services == nil || hp.Services.ServiceToAreas == nil {
+			continue
+		}
+		areas, ok := hp.Services.ServiceToAreas[name]
+		if !ok || !areas.Areas[int32(area)] {
+			continue
+		}
+		lr.Replicas++
+		if hp.Status == l8health.L8HealthState_Up {
+			lr.Up++
+		}
+		lr.Hosts = append(lr.Hosts, hp.Alias)
+	}
+	sort.Strings(lr.Hosts)
+	return lr
+}
@@ -0,0 +1,147 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/saichler/l8types/go/ifs"
+	types3 "github.com/saichler/probler/go/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// LinksIds lists every links id the Links tables route, the runtime ones after
+// the compiled ones and K8sC last as it is an alias of the cluster summary.
+func LinksIds() []string {
+	ids := []string{NetworkDevice_Links_ID, GPU_Links_ID}
+	for _, po := range K8sPrimeObjects {
+		ids = append(ids, po.LinksId)
+	}
+	ids = append(ids, runtimeLinksIds()...)
+	return append(ids, K8sC_Links_ID)
+}
+
+// linkModels returns the model types of every links id, keyed by model name.
+func linkModels() map[string]proto.Message {
+	models := map[string]proto.Message{
+		NetDev_Model_Name: &types3.NetworkDevice{},
+		GPU_Model_Name:    &types3.GpuDevice{},
+	}
+	for _, po := range K8sPrimeObjects {
+		models[po.ModelName()] = po.Model
+	}
+	return models
+}
+
+// RegisterLinkModels registers the type of every compiled links model and its
+// list, which every process routes and so must be able to deserialize.
+func RegisterLinkModels(resources ifs.IResources) {
+	resources.Registry().Register(&types3.NetworkDevice{})
+	resources.Registry().Register(&types3.NetworkDeviceList{})
+	resources.Registry().Register(&types3.GpuDevice{})
+	resources.Registry().Register(&types3.GpuDeviceList{})
+	for _, po := range K8sPrimeObjects {
+		resources.Registry().Register(po.Model)
+		resources.Registry().Register(po.List)
+	}
+}
+
+// ValidateLinks checks the Links routing tables: every links id resolves all
+// five roles and a model, no two links ids or roles share a (service name,
+// service area) pair, and every compiled model names a type already in the
+// registry, see RegisterLinkModels. Runtime models are registered by their own
+// parser and inventory, so only their routes are checked. K8sC is an alias of the cluster summary
+// and may share its services. All problems are reported together, one per line.
+func ValidateLinks(resources ifs.IResources) error {
+	problems := validateRoutes()
+	models := linkModels()
+	for _, linkid := range LinksIds() {
+		model := (&Links{}).Model(linkid)
+		if model == "" {
+			problems = append(problems, linkid+": model does not resolve")
+			continue
+		}
+		if _, ok := runtimeLink(linkid); ok {
+			continue
+		}
+		pb, ok := models[model]
+		if !ok {
+			problems = append(problems, linkid+": model "+model+" matches no known type")
+			continue
+		}
+		typeName := string(pb.ProtoReflect().Descriptor().Name())
+		if _, err := resources.Registry().Info(typeName); err != nil {
+			problems = append(problems, linkid+": model "+model+" is not in the registry: "+err.Error())
+		}
+	}
+	return linksError(problems)
+}
+
+func linksError(problems []string) error {
+	if len(problems) > 0 {
+		return errors.New("invalid Links tables:\n  " + strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// validateRoutes checks that every links id resolves its services and that no
+// two of them share a (service name, service area) pair.
+func validateRoutes() []string {
+	links := &Links{}
+	problems := make([]string, 0)
+	owners := make(map[string]string)
+	claim := func(linkid, role, name string, area byte) {
+		if name == "" {
+			problems = append(problems, linkid+": "+role+" does not resolve")
+			return
+		}
+		pair := name + "/" + strconv.Itoa(int(area))
+		owner := linkid + " " + role
+		if other, ok := owners[pair]; ok && other != owner {
+			problems = append(problems, owner+": service "+pair+" is already used by "+other)
+			return
+		}
+		owners[pair] = owner
+	}
+
+	collectors := make(map[string]bool)
+	for _, linkid := range LinksIds() {
+		name, area := links.Collector(linkid)
+		if name == "" {
+			problems = append(problems, linkid+": collector does not resolve")
+		} else {
+			collectors[name+"/"+strconv.Itoa(int(area))] = true
+		}
+		if linkid == K8sC_Links_ID {
+			continue
+		}
+		name, area = links.Parser(linkid)
+		claim(linkid, "parser", name, area)
+		name, area = links.Cache(linkid)
+		claim(linkid, "cache", name, area)
+		name, area = links.Persist(linkid)
+		claim(linkid, "persist", name, area)
+	}
+	for pair := range collectors {
+		if owner, ok := owners[pair]; ok {
+			problems = append(problems, "collector service "+pair+" is also used by "+owner)
+		}
+	}
+
+	return problems
+}
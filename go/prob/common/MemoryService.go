@@ -0,0 +1,206 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8api"
+	"github.com/saichler/l8utils/go/utils/web"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MemoryService keeps the elements of one model in the process, keyed by its
+// primary keys, and answers the same Post, Put, Patch, Delete and Get a
+// persisting service does. It stands in for a Postgres backed service on the
+// memory data store; everything is lost when the process exits.
+type MemoryService struct {
+	serviceName string
+	serviceArea byte
+	model       proto.Message
+	list        proto.Message
+	keys        []protoreflect.FieldDescriptor
+	mtx         sync.RWMutex
+	elements    map[string]proto.Message
+}
+
+// NewMemoryService returns the handler of a model whose list type holds the
+// elements in its list field.
+func NewMemoryService(model, list proto.Message, primaryKeys ...string) (*MemoryService, error) {
+	this := &MemoryService{model: model, list: list, elements: make(map[string]proto.Message)}
+	fields := model.ProtoReflect().Descriptor().Fields()
+	for _, key := range primaryKeys {
+		fd := fieldByGoName(fields, key)
+		if fd == nil {
+			return nil, fmt.Errorf("%s has no field %s", model.ProtoReflect().Descriptor().Name(), key)
+		}
+		this.keys = append(this.keys, fd)
+	}
+	if len(this.keys) == 0 {
+		return nil, errors.New("a memory service needs primary keys")
+	}
+	if fieldByGoName(list.ProtoReflect().Descriptor().Fields(), "List") == nil {
+		return nil, fmt.Errorf("%s has no list field", list.ProtoReflect().Descriptor().Name())
+	}
+	return this, nil
+}
+
+// fieldByGoName finds TargetId as target_id.
+func fieldByGoName(fields protoreflect.FieldDescriptors, name string) protoreflect.FieldDescriptor {
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if strings.EqualFold(strings.ReplaceAll(string(fd.Name()), "_", ""), name) {
+			return fd
+		}
+	}
+	return nil
+}
+
+func (this *MemoryService) Activate(sla *ifs.ServiceLevelAgreement, vnic ifs.IVNic) error {
+	this.serviceName = sla.ServiceName()
+	this.serviceArea = sla.ServiceArea()
+	vnic.Resources().Registry().Register(this.model)
+	vnic.Resources().Registry().Register(this.list)
+	return nil
+}
+
+func (this *MemoryService) DeActivate() error {
+	return nil
+}
+
+// key joins the quoted primary key values, so a value holding the separator
+// cannot collide with another element's key.
+func (this *MemoryService) key(m proto.Message) string {
+	r := m.ProtoReflect()
+	parts := make([]string, len(this.keys))
+	for i, fd := range this.keys {
+		parts[i] = strconv.Quote(r.Get(fd).String())
+	}
+	return strings.Join(parts, "/")
+}
+
+// each runs do on every element of pb once all of them are of the service
+// model, so a bad element leaves the stored ones as they were.
+func (this *MemoryService) each(pb ifs.IElements, do func(m proto.Message)) ifs.IElements {
+	name := this.model.ProtoReflect().Descriptor().FullName()
+	elements := pb.Elements()
+	checked := make([]proto.Message, 0, len(elements))
+	for _, e := range elements {
+		m, ok := e.(proto.Message)
+		if !ok || m.ProtoReflect().Descriptor().FullName() != name {
+			return object.New(fmt.Errorf("%s expects %s, got %T", this.serviceName, name, e), nil)
+		}
+		checked = append(checked, m)
+	}
+	for _, m := range checked {
+		do(m)
+	}
+	return object.New(nil, nil)
+}
+
+// Post adds the elements, replacing the ones with the same keys.
+func (this *MemoryService) Post(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	return this.each(pb, func(m proto.Message) {
+		this.elements[this.key(m)] = proto.Clone(m)
+	})
+}
+
+func (this *MemoryService) Put(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return this.Post(pb, vnic)
+}
+
+// Patch merges the set fields of the elements into the stored ones.
+func (this *MemoryService) Patch(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	return this.each(pb, func(m proto.Message) {
+		key := this.key(m)
+		if old, ok := this.elements[key]; ok {
+			proto.Merge(old, m)
+			return
+		}
+		this.elements[key] = proto.Clone(m)
+	})
+}
+
+func (this *MemoryService) Delete(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	return this.each(pb, func(m proto.Message) {
+		delete(this.elements, this.key(m))
+	})
+}
+
+// Get returns the elements matching the query in the list type.
+func (this *MemoryService) Get(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	query, err := pb.Query(vnic.Resources())
+	if err != nil {
+		return object.New(err, nil)
+	}
+	return object.New(nil, this.List(func(m proto.Message) bool {
+		return query == nil || query.Match(m)
+	}))
+}
+
+// List returns copies of the stored elements match accepts in the list type,
+// sorted by their keys. A nil match accepts every element.
+func (this *MemoryService) List(match func(m proto.Message) bool) proto.Message {
+	list := this.list.ProtoReflect().New()
+	fd := fieldByGoName(list.Descriptor().Fields(), "List")
+	values := list.Mutable(fd).List()
+	this.mtx.RLock()
+	defer this.mtx.RUnlock()
+	keys := make([]string, 0, len(this.elements))
+	for key := range this.elements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		m := this.elements[key]
+		if match == nil || match(m) {
+			values.Append(protoreflect.ValueOfMessage(proto.Clone(m).ProtoReflect()))
+		}
+	}
+	return list.Interface()
+}
+
+func (this *MemoryService) Failed(pb ifs.IElements, vnic ifs.IVNic, msg *ifs.Message) ifs.IElements {
+	return nil
+}
+
+func (this *MemoryService) TransactionConfig() ifs.ITransactionConfig {
+	return nil
+}
+
+func (this *MemoryService) WebService() ifs.IWebService {
+	ws := web.New(this.serviceName, this.serviceArea, 0)
+	ws.AddEndpoint(this.model, ifs.POST, this.model)
+	ws.AddEndpoint(this.model, ifs.PUT, this.model)
+	ws.AddEndpoint(this.model, ifs.PATCH, this.model)
+	ws.AddEndpoint(this.model, ifs.DELETE, this.model)
+	ws.AddEndpoint(&l8api.L8Query{}, ifs.GET, this.list)
+	return ws
+}
@@ -0,0 +1,330 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/saichler/l8bus/go/overlay/health"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8health"
+)
+
+// dbHealthInterval is how often ReportHealth checks the database.
+const dbHealthInterval = 10 * time.Second
+
+// Migration is one versioned schema change. Versions are applied in order and
+// each one once, recorded in the probler_schema_migrations table.
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// Postgres supervises the database of a store: it starts the local server
+// with /start-postgres.sh or, with dbHost set, connects to an external one,
+// waits until it accepts connections, applies the schema migrations and
+// reports its status to /readyz and /metrics, and with ReportHealth to the
+// health service.
+type Postgres struct {
+	storeType string
+	storeName string
+	host      string
+	port      string
+	user      string
+	pass      string
+	external  bool
+	dataDir   string
+	db        *sql.DB
+	resources ifs.IResources
+}
+
+// NewPostgres resolves the credentials of a store, e.g. the DataStoreConfig
+// or TimeSeriesStoreConfig type and name of the system config.
+func NewPostgres(storeType, storeName string, resources ifs.IResources) (*Postgres, error) {
+	_, user, pass, port, err := resources.Security().Credential(storeType, storeName, resources)
+	if err != nil {
+		return nil, fmt.Errorf("no credentials for %s %s: %s", storeType, storeName, err.Error())
+	}
+	pg := &Postgres{storeType: storeType, storeName: storeName, host: "127.0.0.1", port: port,
+		user: user, pass: pass, resources: resources}
+	if effectiveBoot.DbHost != "" {
+		pg.host = effectiveBoot.DbHost
+		pg.external = true
+	}
+	return pg, nil
+}
+
+// Start brings the database up and migrates it. It fails if the database does
+// not accept connections within dbStartSeconds.
+func (this *Postgres) Start() error {
+	if !this.external {
+		out, err := exec.Command("nohup", "/start-postgres.sh", this.storeName, this.user, this.pass, this.port).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("start-postgres.sh failed: %s: %s", err.Error(), strings.TrimSpace(string(out)))
+		}
+		this.resources.Logger().Info("Postgres started: ", strings.TrimSpace(string(out)))
+		OnShutdown(ShutdownChildren, "postgres", this.stop)
+	}
+
+	db, err := sql.Open("postgres", this.dsn())
+	if err != nil {
+		return err
+	}
+	this.db = db
+
+	timeout := time.Duration(effectiveBoot.DbStartSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 2 * time.Minute
+	}
+	if err = this.waitReady(timeout); err != nil {
+		return err
+	}
+	if !this.external {
+		// The postmaster.pid of the data directory names the server to stop.
+		if err = this.db.QueryRow("show data_directory").Scan(&this.dataDir); err != nil {
+			return fmt.Errorf("failed to read the data directory of %s: %s", this.storeName, err.Error())
+		}
+	}
+	AddReadinessCheck("postgres "+this.storeName, this.ping)
+	AddMetric("probler_db_up", "Whether the database accepts connections.", func() float64 {
+		if this.ping() != nil {
+			return 0
+		}
+		return 1
+	})
+
+	migrations, err := LoadMigrations(filepath.Join(effectiveBoot.DbMigrations, this.storeName))
+	if err != nil {
+		return err
+	}
+	return this.Migrate(migrations)
+}
+
+// dsn is the postgres:// URL of the store, escaping the credentials and the
+// database name.
+func (this *Postgres) dsn() string {
+	sslMode := effectiveBoot.DbSslMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	u := &url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(this.user, this.pass),
+		Host:     net.JoinHostPort(this.host, this.port),
+		Path:     "/" + this.storeName,
+		RawQuery: url.Values{"sslmode": []string{sslMode}}.Encode(),
+	}
+	return u.String()
+}
+
+// ReportHealth reports the database going down, and coming back, as the status
+// of the vnic in the health service, so prctl top and the health table show
+// the process down while its database is.
+func (this *Postgres) ReportHealth(nic ifs.IVNic) {
+	stop := make(chan struct{})
+	OnShutdown(ShutdownIntake, "postgres health "+this.storeName, func(ctx context.Context) error {
+		close(stop)
+		return nil
+	})
+	go func() {
+		ticker := time.NewTicker(dbHealthInterval)
+		defer ticker.Stop()
+		up := true
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			err := this.ping()
+			if (err == nil) == up {
+				continue
+			}
+			up = err == nil
+			sc := nic.Resources().SysConfig()
+			hp := &l8health.L8Health{AUuid: sc.LocalUuid, Alias: sc.LocalAlias, Status: l8health.L8HealthState_Up}
+			if up {
+				this.resources.Logger().Info("Postgres ", this.storeName, " is up again")
+			} else {
+				hp.Status = l8health.L8HealthState_Down
+				this.resources.Logger().Error("Postgres ", this.storeName, " is down: ", err.Error())
+			}
+			if err = nic.Multicast(health.ServiceName, 0, ifs.PATCH, hp); err != nil {
+				this.resources.Logger().Error("Failed to report the ", this.storeName, " status: ", err.Error())
+			}
+		}
+	}()
+}
+
+func (this *Postgres) ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return this.db.PingContext(ctx)
+}
+
+func (this *Postgres) waitReady(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := this.ping()
+		if err == nil {
+			this.resources.Logger().Info("Postgres ", this.host, ":", this.port, "/", this.storeName, " is ready")
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("postgres %s:%s/%s not ready after %s: %s", this.host, this.port, this.storeName,
+				timeout.String(), err.Error())
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// Migrate applies the migrations newer than the recorded schema version, each
+// in its own transaction.
+func (this *Postgres) Migrate(migrations []*Migration) error {
+	if len(migrations) == 0 {
+		return nil
+	}
+	_, err := this.db.Exec(`create table if not exists probler_schema_migrations (
+		version integer primary key,
+		name text not null,
+		applied_at timestamptz not null default now())`)
+	if err != nil {
+		return err
+	}
+	current := 0
+	row := this.db.QueryRow("select coalesce(max(version), 0) from probler_schema_migrations")
+	if err = row.Scan(&current); err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		tx, err := this.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err = tx.Exec(m.SQL); err == nil {
+			_, err = tx.Exec("insert into probler_schema_migrations (version, name) values ($1, $2)", m.Version, m.Name)
+		}
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d %s failed: %s", m.Version, m.Name, err.Error())
+		}
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+		this.resources.Logger().Info("Applied migration ", m.Version, " ", m.Name, " to ", this.storeName)
+	}
+	return nil
+}
+
+// LoadMigrations reads the NNN_name.sql files of a directory, ordered by
+// version. A missing directory has no migrations.
+func LoadMigrations(dir string) ([]*Migration, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, err
+	}
+	migrations := make([]*Migration, 0, len(files))
+	seen := make(map[int]string)
+	for _, file := range files {
+		base := strings.TrimSuffix(filepath.Base(file), ".sql")
+		num, name, _ := strings.Cut(base, "_")
+		version, err := strconv.Atoi(num)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s does not start with a positive version", file)
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, file, version)
+		}
+		seen[version] = file
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, &Migration{Version: version, Name: name, SQL: string(data)})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// stop shuts the local server down with SIGINT to its postmaster, the
+// Postgres fast shutdown: open transactions are rolled back and the data
+// directory is left consistent. Other Postgres instances on the host are not
+// touched.
+func (this *Postgres) stop(ctx context.Context) error {
+	if this.db != nil {
+		this.db.Close()
+	}
+	pid, err := this.postmasterPid()
+	if errors.Is(err, os.ErrNotExist) {
+		// No postmaster.pid, it is already down.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err = syscall.Kill(pid, syscall.SIGINT); err != nil {
+		if errors.Is(err, syscall.ESRCH) {
+			return nil
+		}
+		return err
+	}
+	for syscall.Kill(pid, 0) == nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	return nil
+}
+
+// postmasterPid reads the first line of the postmaster.pid of the data
+// directory.
+func (this *Postgres) postmasterPid() (int, error) {
+	if this.dataDir == "" {
+		return 0, fmt.Errorf("the data directory of %s is unknown", this.storeName)
+	}
+	data, err := os.ReadFile(filepath.Join(this.dataDir, "postmaster.pid"))
+	if err != nil {
+		return 0, err
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	pid, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil {
+		return 0, fmt.Errorf("invalid postmaster.pid of %s: %s", this.storeName, err.Error())
+	}
+	return pid, nil
+}
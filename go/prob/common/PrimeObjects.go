@@ -0,0 +1,149 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"strings"
+
+	types3 "github.com/saichler/probler/go/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// K8s prime object categories, as grouped in the UI.
+const (
+	K8sCategoryCluster       = "Cluster"
+	K8sCategoryWorkloads     = "Workloads"
+	K8sCategoryNetworking    = "Networking"
+	K8sCategoryStorage       = "Storage"
+	K8sCategoryConfiguration = "Configuration"
+	K8sCategoryAccessControl = "Access Control"
+	K8sCategoryNodes         = "Nodes"
+	K8sCategoryNamespaces    = "Namespaces"
+	K8sCategoryVCluster      = "vCluster"
+	K8sCategoryIstio         = "Istio"
+	K8sCategoryCRDs          = "CRDs"
+	K8sCategoryEvents        = "Events"
+)
+
+var (
+	pkClusterKey  = []string{"ClusterName", "Key"}
+	pkClusterName = []string{"ClusterName", "Name"}
+)
+
+// PrimeObject describes one K8s/Istio prime object. It is the single source
+// the parser, the k8s inventory, the web server, the admission controller and
+// the Links tables are built from, so adding a kind is one row here.
+// Name and Aliases are the resource names prctl accepts for the kind; kinds
+// with no Name are not listed by "prctl get".
+// Area is the service area shared by its cache, parser and persist services.
+// Collected objects get a collection target from the admission controller.
+type PrimeObject struct {
+	LinksId     string
+	Name        string
+	Aliases     []string
+	CacheName   string
+	Area        byte
+	Category    string
+	Model       proto.Message
+	List        proto.Message
+	PrimaryKeys []string
+	Namespaced  bool
+	Collected   bool
+}
+
+// ModelName is the lowercase type name used by L8QL queries.
+func (this *PrimeObject) ModelName() string {
+	return strings.ToLower(this.TypeName())
+}
+
+// TypeName is the proto name of the prime object.
+func (this *PrimeObject) TypeName() string {
+	return string(this.Model.ProtoReflect().Descriptor().Name())
+}
+
+// ListTypeName is the proto name of the list type.
+func (this *PrimeObject) ListTypeName() string {
+	return string(this.List.ProtoReflect().Descriptor().Name())
+}
+
+var K8sPrimeObjects = []*PrimeObject{
+	{K8sClust_Links_ID, "", nil, "KCluster", 10, K8sCategoryCluster, &types3.K8SCluster{}, &types3.K8SClusterList{}, []string{"Name"}, false, false},
+	{K8sPod_Links_ID, "pods", []string{"pod", "po"}, "K8sPod", 11, K8sCategoryWorkloads, &types3.K8SPod{}, &types3.K8SPodList{}, pkClusterKey, true, true},
+	{K8sDeploy_Links_ID, "deployments", []string{"deployment", "deploy"}, "K8sDploy", 12, K8sCategoryWorkloads, &types3.K8SDeployment{}, &types3.K8SDeploymentList{}, pkClusterKey, true, true},
+	{K8sSts_Links_ID, "statefulsets", []string{"statefulset", "sts"}, "K8sSts", 13, K8sCategoryWorkloads, &types3.K8SStatefulSet{}, &types3.K8SStatefulSetList{}, pkClusterKey, true, true},
+	{K8sDs_Links_ID, "daemonsets", []string{"daemonset", "ds"}, "K8sDs", 14, K8sCategoryWorkloads, &types3.K8SDaemonSet{}, &types3.K8SDaemonSetList{}, pkClusterKey, true, true},
+	{K8sRs_Links_ID, "replicasets", []string{"replicaset", "rs"}, "K8sRs", 15, K8sCategoryWorkloads, &types3.K8SReplicaSet{}, &types3.K8SReplicaSetList{}, pkClusterKey, true, true},
+	{K8sJob_Links_ID, "jobs", []string{"job"}, "K8sJob", 16, K8sCategoryWorkloads, &types3.K8SJob{}, &types3.K8SJobList{}, pkClusterKey, true, true},
+	{K8sCj_Links_ID, "cronjobs", []string{"cronjob", "cj"}, "K8sCj", 17, K8sCategoryWorkloads, &types3.K8SCronJob{}, &types3.K8SCronJobList{}, pkClusterKey, true, true},
+	{K8sHpa_Links_ID, "hpas", []string{"hpa"}, "K8sHpa", 18, K8sCategoryWorkloads, &types3.K8SHPA{}, &types3.K8SHPAList{}, pkClusterKey, true, true},
+	{K8sSvc_Links_ID, "services", []string{"service", "svc"}, "K8sSvc", 19, K8sCategoryNetworking, &types3.K8SService{}, &types3.K8SServiceList{}, pkClusterKey, true, true},
+	{K8sIng_Links_ID, "ingresses", []string{"ingress", "ing"}, "K8sIng", 20, K8sCategoryNetworking, &types3.K8SIngress{}, &types3.K8SIngressList{}, pkClusterKey, true, true},
+	{K8sNetPol_Links_ID, "networkpolicies", []string{"networkpolicy", "netpol"}, "K8sNtPol", 21, K8sCategoryNetworking, &types3.K8SNetworkPolicy{}, &types3.K8SNetworkPolicyList{}, pkClusterKey, true, true},
+	{K8sEp_Links_ID, "endpoints", []string{"ep"}, "K8sEp", 22, K8sCategoryNetworking, &types3.K8SEndpoints{}, &types3.K8SEndpointsList{}, pkClusterKey, true, true},
+	{K8sEpSl_Links_ID, "endpointslices", []string{"endpointslice"}, "K8sEpSl", 23, K8sCategoryNetworking, &types3.K8SEndpointSlice{}, &types3.K8SEndpointSliceList{}, pkClusterKey, true, true},
+	{K8sIngCl_Links_ID, "ingressclasses", []string{"ingressclass"}, "K8sIngCl", 24, K8sCategoryNetworking, &types3.K8SIngressClass{}, &types3.K8SIngressClassList{}, pkClusterKey, false, true},
+	{K8sPv_Links_ID, "pvs", []string{"pv", "persistentvolumes"}, "K8sPv", 25, K8sCategoryStorage, &types3.K8SPersistentVolume{}, &types3.K8SPersistentVolumeList{}, pkClusterKey, false, true},
+	{K8sPvc_Links_ID, "pvcs", []string{"pvc", "persistentvolumeclaims"}, "K8sPvc", 26, K8sCategoryStorage, &types3.K8SPersistentVolumeClaim{}, &types3.K8SPersistentVolumeClaimList{}, pkClusterKey, true, true},
+	{K8sScl_Links_ID, "storageclasses", []string{"storageclass", "sc"}, "K8sScl", 27, K8sCategoryStorage, &types3.K8SStorageClass{}, &types3.K8SStorageClassList{}, pkClusterKey, false, true},
+	{K8sCm_Links_ID, "configmaps", []string{"configmap", "cm"}, "K8sCm", 28, K8sCategoryConfiguration, &types3.K8SConfigMap{}, &types3.K8SConfigMapList{}, pkClusterKey, true, true},
+	{K8sSec_Links_ID, "secrets", []string{"secret"}, "K8sSec", 29, K8sCategoryConfiguration, &types3.K8SSecret{}, &types3.K8SSecretList{}, pkClusterKey, true, true},
+	{K8sRq_Links_ID, "resourcequotas", []string{"resourcequota", "quota"}, "K8sRq", 30, K8sCategoryConfiguration, &types3.K8SResourceQuota{}, &types3.K8SResourceQuotaList{}, pkClusterKey, true, true},
+	{K8sLr_Links_ID, "limitranges", []string{"limitrange", "limits"}, "K8sLr", 31, K8sCategoryConfiguration, &types3.K8SLimitRange{}, &types3.K8SLimitRangeList{}, pkClusterKey, true, true},
+	{K8sPdb_Links_ID, "pdbs", []string{"pdb", "poddisruptionbudgets"}, "K8sPdb", 32, K8sCategoryConfiguration, &types3.K8SPodDisruptionBudget{}, &types3.K8SPodDisruptionBudgetList{}, pkClusterKey, true, true},
+	{K8sSa_Links_ID, "serviceaccounts", []string{"serviceaccount", "sa"}, "K8sSa", 33, K8sCategoryAccessControl, &types3.K8SServiceAccount{}, &types3.K8SServiceAccountList{}, pkClusterKey, true, true},
+	{K8sRole_Links_ID, "roles", []string{"role"}, "K8sRole", 34, K8sCategoryAccessControl, &types3.K8SRole{}, &types3.K8SRoleList{}, pkClusterKey, true, true},
+	{K8sCr_Links_ID, "clusterroles", []string{"clusterrole"}, "K8sCr", 35, K8sCategoryAccessControl, &types3.K8SClusterRole{}, &types3.K8SClusterRoleList{}, pkClusterKey, false, true},
+	{K8sRb_Links_ID, "rolebindings", []string{"rolebinding"}, "K8sRb", 36, K8sCategoryAccessControl, &types3.K8SRoleBinding{}, &types3.K8SRoleBindingList{}, pkClusterKey, true, true},
+	{K8sCrb_Links_ID, "clusterrolebindings", []string{"clusterrolebinding"}, "K8sCrb", 37, K8sCategoryAccessControl, &types3.K8SClusterRoleBinding{}, &types3.K8SClusterRoleBindingList{}, pkClusterKey, false, true},
+	{K8sNode_Links_ID, "nodes", []string{"node", "no"}, "K8sNode", 38, K8sCategoryNodes, &types3.K8SNode{}, &types3.K8SNodeList{}, pkClusterName, false, true},
+	{K8sNs_Links_ID, "namespaces", []string{"namespace", "ns"}, "K8sNs", 39, K8sCategoryNamespaces, &types3.K8SNamespace{}, &types3.K8SNamespaceList{}, pkClusterName, false, true},
+	{K8sVCl_Links_ID, "vclusters", []string{"vcluster"}, "K8sVCl", 40, K8sCategoryVCluster, &types3.K8SVCluster{}, &types3.K8SVClusterList{}, pkClusterKey, true, true},
+	{IstioVs_Links_ID, "istio-vs", []string{"virtualservices"}, "IstioVs", 41, K8sCategoryIstio, &types3.IstioVirtualService{}, &types3.IstioVirtualServiceList{}, pkClusterKey, true, true},
+	{IstioDr_Links_ID, "istio-dr", []string{"destinationrules"}, "IstioDr", 42, K8sCategoryIstio, &types3.IstioDestinationRule{}, &types3.IstioDestinationRuleList{}, pkClusterKey, true, true},
+	{IstioGw_Links_ID, "istio-gw", []string{"gateways"}, "IstioGw", 43, K8sCategoryIstio, &types3.IstioGateway{}, &types3.IstioGatewayList{}, pkClusterKey, true, true},
+	{IstioSe_Links_ID, "istio-se", []string{"serviceentries"}, "IstioSe", 44, K8sCategoryIstio, &types3.IstioServiceEntry{}, &types3.IstioServiceEntryList{}, pkClusterKey, true, true},
+	{IstioPa_Links_ID, "istio-pa", []string{"peerauthentications"}, "IstioPa", 45, K8sCategoryIstio, &types3.IstioPeerAuthentication{}, &types3.IstioPeerAuthenticationList{}, pkClusterKey, true, true},
+	{IstioAp_Links_ID, "istio-ap", []string{"authorizationpolicies"}, "IstioAp", 46, K8sCategoryIstio, &types3.IstioAuthorizationPolicy{}, &types3.IstioAuthorizationPolicyList{}, pkClusterKey, true, true},
+	{IstioSc_Links_ID, "istio-sc", []string{"sidecars"}, "IstioSc", 47, K8sCategoryIstio, &types3.IstioSidecar{}, &types3.IstioSidecarList{}, pkClusterKey, true, true},
+	{IstioEf_Links_ID, "istio-ef", []string{"envoyfilters"}, "IstioEf", 48, K8sCategoryIstio, &types3.IstioEnvoyFilter{}, &types3.IstioEnvoyFilterList{}, pkClusterKey, true, true},
+	{K8sCrd_Links_ID, "crds", []string{"crd", "customresourcedefinitions"}, "K8sCrd", 49, K8sCategoryCRDs, &types3.K8SCRD{}, &types3.K8SCRDList{}, pkClusterName, false, true},
+	{K8sEvt_Links_ID, "events", []string{"event", "ev"}, "K8sEvt", 50, K8sCategoryEvents, &types3.K8SEvent{}, &types3.K8SEventList{}, pkClusterKey, true, true},
+}
+
+// K8sCRDKinds are the prime objects served by custom resources, with the
+// group/version/resource that may serve each. A cluster serving none of them
+// has no such objects, so adcon does not collect the kind there.
+var K8sCRDKinds = map[string][]string{
+	K8sVCl_Links_ID:  {"management.loft.sh/v1/virtualclusterinstances", "infrastructure.cluster.x-k8s.io/v1alpha1/vclusters"},
+	IstioVs_Links_ID: {"networking.istio.io/v1beta1/virtualservices"},
+	IstioDr_Links_ID: {"networking.istio.io/v1beta1/destinationrules"},
+	IstioGw_Links_ID: {"networking.istio.io/v1beta1/gateways"},
+	IstioSe_Links_ID: {"networking.istio.io/v1beta1/serviceentries"},
+	IstioSc_Links_ID: {"networking.istio.io/v1beta1/sidecars"},
+	IstioEf_Links_ID: {"networking.istio.io/v1alpha3/envoyfilters"},
+	IstioPa_Links_ID: {"security.istio.io/v1beta1/peerauthentications"},
+	IstioAp_Links_ID: {"security.istio.io/v1beta1/authorizationpolicies"},
+}
+
+// PrimeObjectByLinksId returns the prime object registered for a links id.
+func PrimeObjectByLinksId(linksId string) (*PrimeObject, bool) {
+	for _, po := range K8sPrimeObjects {
+		if po.LinksId == linksId {
+			return po, true
+		}
+	}
+	return nil, false
+}
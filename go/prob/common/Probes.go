@@ -0,0 +1,196 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8health"
+)
+
+// ProbeCheck reports why a process is not alive or not ready, nil if it is.
+type ProbeCheck func() error
+
+// Metric returns the current value of a gauge served on /metrics.
+type Metric func() float64
+
+type probeCheck struct {
+	name  string
+	check ProbeCheck
+}
+
+type probeMetric struct {
+	name   string
+	help   string
+	metric Metric
+}
+
+// probes backs the /healthz, /readyz and /metrics endpoints. A process is
+// ready once WaitForSignal is reached, i.e. every Activate call in its main
+// returned, and every readiness check passes. It stops being ready as soon as
+// shutdown starts so Kubernetes holds traffic while the hooks drain.
+var probes = struct {
+	mtx       sync.RWMutex
+	alive     []*probeCheck
+	ready     []*probeCheck
+	metrics   []*probeMetric
+	activated atomic.Bool
+	stopping  atomic.Bool
+	started   time.Time
+}{started: time.Now()}
+
+// AddLivenessCheck adds a check to /healthz. A failing liveness check gets the
+// pod restarted, so only add checks that a restart can fix.
+func AddLivenessCheck(name string, check ProbeCheck) {
+	probes.mtx.Lock()
+	defer probes.mtx.Unlock()
+	probes.alive = append(probes.alive, &probeCheck{name: name, check: check})
+}
+
+// AddReadinessCheck adds a check to /readyz, e.g. a database connection.
+func AddReadinessCheck(name string, check ProbeCheck) {
+	probes.mtx.Lock()
+	defer probes.mtx.Unlock()
+	probes.ready = append(probes.ready, &probeCheck{name: name, check: check})
+}
+
+// AddMetric adds a gauge to /metrics. name should follow the Prometheus
+// naming rules, e.g. probler_targets_polled.
+func AddMetric(name, help string, metric Metric) {
+	probes.mtx.Lock()
+	defer probes.mtx.Unlock()
+	probes.metrics = append(probes.metrics, &probeMetric{name: name, help: help, metric: metric})
+}
+
+// startProbes serves the probe endpoints on the given port. They are disabled
+// with port 0.
+func startProbes(port int, resources ifs.IResources) {
+	if port == 0 {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeProbe(w, probeFailures(false))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		failures := probeFailures(true)
+		if !probes.activated.Load() {
+			failures = append(failures, "activate: services are still starting")
+		}
+		if probes.stopping.Load() {
+			failures = append(failures, "shutdown: in progress")
+		}
+		writeProbe(w, failures)
+	})
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writeMetrics(w)
+	})
+	OnShutdown(ShutdownIntake, "probes", func(ctx context.Context) error {
+		probes.stopping.Store(true)
+		return nil
+	})
+	go func() {
+		err := http.ListenAndServe(":"+strconv.Itoa(port), mux)
+		resources.Logger().Error("Probe endpoints on port ", port, " stopped: ", err.Error())
+	}()
+}
+
+// VnetConnected fails unless the health service, as the vnet replicates it,
+// reports the vnic up.
+func VnetConnected(nic ifs.IVNic) error {
+	top, err := healthTop(nic)
+	if err != nil {
+		return err
+	}
+	hp, ok := top.Healths[nic.Resources().SysConfig().LocalUuid]
+	if !ok {
+		return errors.New("not known to the vnet yet")
+	}
+	if hp.Status != l8health.L8HealthState_Up {
+		return fmt.Errorf("vnet reports %s", hp.Status.String())
+	}
+	return nil
+}
+
+// probeFailures runs the readiness checks, or the liveness checks when ready
+// is false. The checks are copied under the lock and run outside of it.
+func probeFailures(ready bool) []string {
+	probes.mtx.RLock()
+	list := probes.alive
+	if ready {
+		list = probes.ready
+	}
+	checks := make([]*probeCheck, len(list))
+	copy(checks, list)
+	probes.mtx.RUnlock()
+	failures := make([]string, 0)
+	for _, c := range checks {
+		if err := c.check(); err != nil {
+			failures = append(failures, c.name+": "+err.Error())
+		}
+	}
+	return failures
+}
+
+func writeProbe(w http.ResponseWriter, failures []string) {
+	if len(failures) > 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		for _, f := range failures {
+			fmt.Fprintln(w, f)
+		}
+		return
+	}
+	fmt.Fprintln(w, "ok")
+}
+
+func writeMetrics(w http.ResponseWriter) {
+	mem := &runtime.MemStats{}
+	runtime.ReadMemStats(mem)
+	ready := 0.0
+	if probes.activated.Load() && !probes.stopping.Load() && len(probeFailures(true)) == 0 {
+		ready = 1
+	}
+	gauges := []*probeMetric{
+		{"probler_ready", "Whether the process passes its readiness checks.", func() float64 { return ready }},
+		{"process_uptime_seconds", "Seconds since the process started.", func() float64 { return time.Since(probes.started).Seconds() }},
+		{"go_goroutines", "Number of goroutines.", func() float64 { return float64(runtime.NumGoroutine()) }},
+		{"go_memstats_heap_alloc_bytes", "Bytes of allocated heap objects.", func() float64 { return float64(mem.HeapAlloc) }},
+		{"go_memstats_sys_bytes", "Bytes of memory obtained from the OS.", func() float64 { return float64(mem.Sys) }},
+		{"go_gc_cycles_total", "Number of completed GC cycles.", func() float64 { return float64(mem.NumGC) }},
+	}
+	probes.mtx.RLock()
+	custom := make([]*probeMetric, len(probes.metrics))
+	copy(custom, probes.metrics)
+	probes.mtx.RUnlock()
+	sort.Slice(custom, func(i, j int) bool {
+		return custom[i].name < custom[j].name
+	})
+	for _, m := range append(gauges, custom...) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %s\n", m.name, m.help, m.name, m.name,
+			strconv.FormatFloat(m.metric(), 'g', -1, 64))
+	}
+}
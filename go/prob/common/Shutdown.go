@@ -0,0 +1,227 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/saichler/l8pollaris/go/pollaris/targets"
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+)
+
+// Shutdown phases, run in this order. Hooks of one phase run concurrently and
+// the next phase starts once they all returned.
+const (
+	// ShutdownIntake stops accepting new jobs and requests.
+	ShutdownIntake = iota
+	// ShutdownFlush writes cached state, e.g. inventory caches to persistence.
+	ShutdownFlush
+	// ShutdownNetwork deregisters from the vnet.
+	ShutdownNetwork
+	// ShutdownChildren stops child processes such as Postgres.
+	ShutdownChildren
+	shutdownPhases
+)
+
+var shutdownPhaseNames = []string{"intake", "flush", "network", "children"}
+
+// ShutdownHook releases one resource. It should return once ctx is done even
+// if it did not finish.
+type ShutdownHook func(ctx context.Context) error
+
+type shutdownHook struct {
+	name string
+	hook ShutdownHook
+}
+
+// ShutdownHooks are the hooks of one process, run phase by phase within a
+// deadline. The package functions use the process wide set.
+type ShutdownHooks struct {
+	mtx      sync.Mutex
+	hooks    [shutdownPhases][]*shutdownHook
+	deadline time.Duration
+}
+
+// NewShutdownHooks returns an empty set whose hooks must finish within
+// deadline.
+func NewShutdownHooks(deadline time.Duration) *ShutdownHooks {
+	return &ShutdownHooks{deadline: deadline}
+}
+
+var shutdown = NewShutdownHooks(30 * time.Second)
+
+// Add registers a hook to run in the given phase.
+func (this *ShutdownHooks) Add(phase int, name string, hook ShutdownHook) {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	this.hooks[phase] = append(this.hooks[phase], &shutdownHook{name: name, hook: hook})
+}
+
+// Run runs the hooks phase by phase within the deadline. Hooks still running
+// at the deadline are abandoned, the later phases do not run and the error
+// names the phase.
+func (this *ShutdownHooks) Run(resources ifs.IResources) error {
+	this.mtx.Lock()
+	hooks := this.hooks
+	deadline := this.deadline
+	this.mtx.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), deadline)
+	defer cancel()
+	for phase, list := range hooks {
+		if len(list) == 0 {
+			continue
+		}
+		resources.Logger().Info("Shutdown: ", shutdownPhaseNames[phase])
+		wg := &sync.WaitGroup{}
+		for _, h := range list {
+			wg.Add(1)
+			go func(h *shutdownHook) {
+				defer wg.Done()
+				if err := h.hook(ctx); err != nil {
+					resources.Logger().Error("Shutdown hook ", h.name, " failed: ", err.Error())
+				}
+			}(h)
+		}
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return fmt.Errorf("shutdown deadline of %s passed during %s", deadline.String(), shutdownPhaseNames[phase])
+		}
+	}
+	return nil
+}
+
+// OnShutdown registers a hook to run in the given phase when the process is
+// asked to stop.
+func OnShutdown(phase int, name string, hook ShutdownHook) {
+	shutdown.Add(phase, name, hook)
+}
+
+// OnShutdownVnic deregisters the vnic from the vnet on shutdown.
+func OnShutdownVnic(nic ifs.IVNic) {
+	OnShutdown(ShutdownNetwork, "vnic", func(ctx context.Context) error {
+		nic.Shutdown()
+		return nil
+	})
+}
+
+// OnShutdownService deactivates a service of the vnic in the given phase, e.g.
+// the collector and parsers at intake so no new job starts.
+func OnShutdownService(phase int, nic ifs.IVNic, serviceName string, serviceArea byte) {
+	OnShutdown(phase, serviceName+"/"+strconv.Itoa(int(serviceArea)), func(ctx context.Context) error {
+		return deactivateService(nic, serviceName, serviceArea)
+	})
+}
+
+func deactivateService(nic ifs.IVNic, serviceName string, serviceArea byte) error {
+	handler, ok := nic.Resources().Services().ServiceHandler(serviceName, serviceArea)
+	if !ok {
+		return nil
+	}
+	return handler.DeActivate()
+}
+
+// OnShutdownFlush writes the inventory cache of a links id to its persist
+// service at flush, while the vnic is still on the vnet, and waits for the
+// write before it deactivates the cache.
+func OnShutdownFlush(nic ifs.IVNic, linkid string) {
+	cacheName, cacheArea := targets.Links.Cache(linkid)
+	OnShutdown(ShutdownFlush, cacheName+"/"+strconv.Itoa(int(cacheArea)), func(ctx context.Context) error {
+		err := flushCache(ctx, nic, linkid)
+		if e := deactivateService(nic, cacheName, cacheArea); err == nil {
+			err = e
+		}
+		return err
+	})
+}
+
+// flushCache reads every element of the local cache of linkid and posts them,
+// in its list type, to the persist service.
+func flushCache(ctx context.Context, nic ifs.IVNic, linkid string) error {
+	cacheName, cacheArea := targets.Links.Cache(linkid)
+	persistName, persistArea := targets.Links.Persist(linkid)
+	if persistName == "" {
+		return nil
+	}
+	handler, ok := nic.Resources().Services().ServiceHandler(cacheName, cacheArea)
+	if !ok {
+		return nil
+	}
+	elems, err := object.NewQuery("select * from "+targets.Links.Model(linkid), nic.Resources())
+	if err != nil {
+		return err
+	}
+	resp := handler.Get(elems, nic)
+	if resp == nil {
+		return nil
+	}
+	if resp.Error() != nil {
+		return resp.Error()
+	}
+	list := resp.Element()
+	if list == nil {
+		return nil
+	}
+	timeout := 5
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = int(time.Until(deadline).Seconds())
+		if timeout < 1 {
+			return ctx.Err()
+		}
+	}
+	resp = nic.LeaderRequest(persistName, persistArea, ifs.POST, list, timeout)
+	if resp != nil && resp.Error() != nil {
+		return fmt.Errorf("flush %s to %s: %s", cacheName, persistName, resp.Error().Error())
+	}
+	nic.Resources().Logger().Info("Shutdown: flushed ", cacheName, " to ", persistName)
+	return nil
+}
+
+// Shutdown runs the registered hooks phase by phase within the shutdown
+// deadline. Hooks still running at the deadline are abandoned and reported.
+func Shutdown(resources ifs.IResources) {
+	if err := shutdown.Run(resources); err != nil {
+		resources.Logger().Error(err.Error())
+		return
+	}
+	resources.Logger().Info("Shutdown complete")
+}
+
+// setShutdownDeadline is applied from the bootstrap config.
+func setShutdownDeadline(deadline time.Duration) {
+	shutdown.mtx.Lock()
+	defer shutdown.mtx.Unlock()
+	shutdown.deadline = deadline
+}
+
+// exitOnSecondSignal lets an operator skip a stuck shutdown.
+func exitOnSecondSignal(sigs chan os.Signal, resources ifs.IResources) {
+	sig := <-sigs
+	resources.Logger().Error("Second signal received, exiting without finishing shutdown ", sig)
+	os.Exit(1)
+}
@@ -0,0 +1,295 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package activate holds the service activation of every probler binary, so
+// each main and the all in one binary start the same services the same way.
+package activate
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/saichler/l8alarms/go/alm/services"
+	collector "github.com/saichler/l8collector/go/collector/common"
+	service2 "github.com/saichler/l8collector/go/collector/service"
+	services2 "github.com/saichler/l8events/go/services"
+	inventory "github.com/saichler/l8inventory/go/inv/service"
+	"github.com/saichler/l8parser/go/parser/rules"
+	"github.com/saichler/l8parser/go/parser/service"
+	"github.com/saichler/l8pollaris/go/pollaris"
+	"github.com/saichler/l8pollaris/go/pollaris/targets"
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+	"github.com/saichler/l8topology/go/topo/discover"
+	"github.com/saichler/l8topology/go/topo/topo_list"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/probler/go/prob/common"
+	"github.com/saichler/probler/go/prob/parser/serializers"
+	serializers2 "github.com/saichler/probler/go/serializers"
+	types2 "github.com/saichler/probler/go/types"
+)
+
+// Vnic registers the shutdown hook and the probe checks of a started vnic:
+// /healthz fails once the vnic stopped running and /readyz until the vnet
+// reports the vnic up. It also activates the debug service of the process.
+func Vnic(nic ifs.IVNic) {
+	common.OnShutdownVnic(nic)
+	alias := nic.Resources().SysConfig().LocalAlias
+	common.AddLivenessCheck("vnic "+alias, func() error {
+		if !nic.Running() {
+			return errors.New("not running")
+		}
+		return nil
+	})
+	common.AddReadinessCheck("vnet "+alias, func() error {
+		return common.VnetConnected(nic)
+	})
+	Debug(nic)
+}
+
+// Parser activates pollaris and the parsers of every links id.
+func Parser(nic ifs.IVNic) {
+	nic.Resources().Registry().RegisterEnums(types2.K8SPodStatus_value)
+	nic.Resources().Registry().Register(&types2.K8SReadyState{})
+	info, _ := nic.Resources().Registry().Info("K8SReadyState")
+	info.AddSerializer(&serializers.Ready{})
+
+	nic.Resources().Registry().Register(&types2.K8SRestartsState{})
+	infoR, _ := nic.Resources().Registry().Info("K8SRestartsState")
+	infoR.AddSerializer(&serializers.Restarts{})
+
+	// Register string→int32 maps for typed-enum fields populated from raw
+	// K8s API strings. The keys here mirror what the K8s API returns
+	// directly ("Running", "Ready", …) and what the collector's enrichment
+	// emits for derived statuses ("Ready" / "NotReady" from
+	// status.conditions[type=Ready]). Without this, the parser's
+	// setFieldValue would either reject the assignment or convert string→
+	// int32 via a rune cast and leave the proto field at 0 (UNSPECIFIED) —
+	// which the UI rendered as a permanent "Unknown".
+	rules.RegisterEnum("K8SPodStatus", map[string]int32{
+		"Running":           int32(types2.K8SPodStatus_K8S_POD_STATUS_RUNNING),
+		"Pending":           int32(types2.K8SPodStatus_K8S_POD_STATUS_PENDING),
+		"Succeeded":         int32(types2.K8SPodStatus_K8S_POD_STATUS_SUCCEEDED),
+		"Failed":            int32(types2.K8SPodStatus_K8S_POD_STATUS_FAILED),
+		"Unknown":           int32(types2.K8SPodStatus_K8S_POD_STATUS_UNKNOWN),
+		"CrashLoopBackOff":  int32(types2.K8SPodStatus_K8S_POD_STATUS_CRASHLOOPBACKOFF),
+		"Terminating":       int32(types2.K8SPodStatus_K8S_POD_STATUS_TERMINATING),
+		"ContainerCreating": int32(types2.K8SPodStatus_K8S_POD_STATUS_CONTAINERCREATING),
+		"ImagePullBackOff":  int32(types2.K8SPodStatus_K8S_POD_STATUS_IMAGEPULLBACKOFF),
+		"Error":             int32(types2.K8SPodStatus_K8S_POD_STATUS_ERROR),
+		"Completed":         int32(types2.K8SPodStatus_K8S_POD_STATUS_COMPLETED),
+	})
+	rules.RegisterEnum("K8SNodeStatus", map[string]int32{
+		"Ready":    int32(types2.K8SNodeStatus_K8S_NODE_STATUS_READY),
+		"NotReady": int32(types2.K8SNodeStatus_K8S_NODE_STATUS_NOT_READY),
+	})
+
+	//Activate Polaris
+	pollaris.Activate(nic)
+
+	//Activate Inventory parser
+	service.Activate(common.NetworkDevice_Links_ID, &types2.NetworkDevice{}, false, nic, "Id")
+
+	// Activate Kubernetes parsers, one per prime object
+	for _, po := range common.K8sPrimeObjects {
+		service.Activate(po.LinksId, po.Model, false, nic, po.PrimaryKeys...)
+	}
+
+	//Activate GPU parser
+	service.Activate(common.GPU_Links_ID, &types2.GpuDevice{}, false, nic, "Id")
+
+	// Stop parsing at intake, K8sC shares the parser of the cluster kind.
+	seen := make(map[string]bool)
+	for _, linkid := range common.LinksIds() {
+		name, area := targets.Links.Parser(linkid)
+		key := name + "/" + strconv.Itoa(int(area))
+		if _, ok := nic.Resources().Services().ServiceHandler(name, area); ok && !seen[key] {
+			seen[key] = true
+			common.OnShutdownService(common.ShutdownIntake, nic, name, area)
+		}
+	}
+}
+
+// Collector activates pollaris and the collector.
+func Collector(nic ifs.IVNic) {
+	collector.SmoothFirstCollection = true
+
+	//Activate pollaris
+	pollaris.Activate(nic)
+
+	//no need to activate with links id k8s as they are the same area for collection
+	service2.Activate(common.NetworkDevice_Links_ID, nic)
+	common.OnShutdownService(common.ShutdownIntake, nic, common.Collector_Service_Name, common.Collector_Service_Area)
+}
+
+// Box activates the network device inventory.
+func Box(nic ifs.IVNic) {
+	inventory.Activate(common.NetworkDevice_Links_ID, &types2.NetworkDevice{}, &types2.NetworkDeviceList{}, nic, "Id")
+
+	s, a := targets.Links.Cache(common.NetworkDevice_Links_ID)
+	invCenter := inventory.Inventory(nic.Resources(), s, a)
+	invCenter.AddMetadata("Online", deviceOnline)
+	common.OnShutdownFlush(nic, common.NetworkDevice_Links_ID)
+}
+
+func deviceOnline(any interface{}) (bool, string) {
+	if any == nil {
+		return false, ""
+	}
+	nd := any.(*types2.NetworkDevice)
+	if nd.Equipmentinfo == nil {
+		return false, ""
+	}
+	if nd.Equipmentinfo.DeviceStatus == types2.DeviceStatus_DEVICE_STATUS_ONLINE {
+		return true, ""
+	}
+	return false, ""
+}
+
+// Gpu activates the GPU inventory.
+func Gpu(nic ifs.IVNic) {
+	//Add the inventory model and mark the Id field as key
+	nic.Resources().Introspector().Decorators().AddPrimaryKeyDecorator(&types2.GpuDevice{}, "Id")
+
+	//Activate the box inventory service with the primary key & sample model instance
+	inventory.Activate(common.GPU_Links_ID, &types2.GpuDevice{}, &types2.GpuDeviceList{}, nic, "Id")
+
+	s, a := targets.Links.Cache(common.GPU_Links_ID)
+	invCenter := inventory.Inventory(nic.Resources(), s, a)
+	invCenter.AddMetadata("Online", gpuOnline)
+	common.OnShutdownFlush(nic, common.GPU_Links_ID)
+}
+
+func gpuOnline(any interface{}) (bool, string) {
+	if any == nil {
+		return false, ""
+	}
+	nd := any.(*types2.GpuDevice)
+	if nd.DeviceInfo == nil {
+		return false, ""
+	}
+	if nd.DeviceInfo.DeviceStatus == types2.DeviceStatus_DEVICE_STATUS_ONLINE {
+		return true, ""
+	}
+	return false, ""
+}
+
+// K8sInventory activates the inventory of every K8s prime object.
+func K8sInventory(nic ifs.IVNic) {
+	registerK8sSerializers(nic)
+
+	for _, po := range common.K8sPrimeObjects {
+		inventory.Activate(po.LinksId, po.Model, po.List, nic, po.PrimaryKeys...)
+		common.OnShutdownFlush(nic, po.LinksId)
+	}
+}
+
+func registerK8sSerializers(nic ifs.IVNic) {
+	nic.Resources().Registry().Register(&types2.K8SReadyState{})
+	nic.Resources().Registry().Register(&types2.K8SRestartsState{})
+
+	info, err := nic.Resources().Registry().Info("K8SReadyState")
+	if err != nil {
+		nic.Resources().Logger().Error(err)
+	} else {
+		info.AddSerializer(&serializers2.Ready{})
+	}
+
+	info, err = nic.Resources().Registry().Info("K8SRestartsState")
+	if err != nil {
+		nic.Resources().Logger().Error(err)
+	} else {
+		info.AddSerializer(&serializers2.Restarts{})
+	}
+
+	nic.Resources().Registry().RegisterEnums(types2.K8SPodStatus_value)
+	nic.Resources().Registry().RegisterEnums(types2.K8SNodeStatus_value)
+}
+
+// Topology activates the topology catalog, the layer 1 discovery and the links
+// service.
+func Topology(nic ifs.IVNic) {
+	topo_list.Activate(nic)
+	discover.ActivateLayer1(nic)
+	Links(nic)
+}
+
+// Links activates the links service, serving the collector, parser, cache and
+// persist hosts of every links id.
+func Links(nic ifs.IVNic) {
+	sla := ifs.NewServiceLevelAgreement(&common.LinksService{}, common.Links_Service_Name, common.Links_Service_Area, false, nil)
+	nic.Resources().Services().Activate(sla, nic)
+}
+
+// Debug activates the debug service, taking the log level, links trace and
+// dump controls of the process.
+func Debug(nic ifs.IVNic) {
+	sla := ifs.NewServiceLevelAgreement(&common.DebugService{}, common.Debug_Service_Name, common.Debug_Service_Area, false, nil)
+	nic.Resources().Services().Activate(sla, nic)
+}
+
+// Orm activates the targets and events services on the data store, once it
+// is up: the local Postgres, the external one of dbHost or, with the memory
+// backend, the process itself.
+func Orm(nic ifs.IVNic) error {
+	sc := nic.Resources().SysConfig().DataStoreConfig
+	store, err := startStore(nic, sc.Type, sc.Name)
+	if err != nil {
+		return err
+	}
+
+	//Activate targets
+	err = store.Activate(&common.StoreService{Name: targets.ServiceName, Area: 0,
+		Model: &l8tpollaris.L8PTarget{}, List: &l8tpollaris.L8PTargetList{}, PrimaryKeys: []string{"TargetId"},
+		Activate: func(storeType, storeName string, nic ifs.IVNic) {
+			targets.Activate(storeType, storeName, nic)
+		}}, nic)
+	if err != nil {
+		return err
+	}
+
+	//Activate Events
+	return store.Activate(&common.StoreService{Name: "events",
+		Activate: func(storeType, storeName string, nic ifs.IVNic) {
+			services2.ActivateEvents(storeType, storeName, nic)
+		}}, nic)
+}
+
+// Alarms activates the alarm services on the time series store, once it is
+// up. The alarm types must be registered before the vnic starts, see
+// ui.RegisterAlmTypes.
+func Alarms(nic ifs.IVNic) error {
+	sc := nic.Resources().SysConfig().TimeSeriesStoreConfig
+	store, err := startStore(nic, sc.Type, sc.Name)
+	if err != nil {
+		return err
+	}
+	return store.Activate(&common.StoreService{Name: "alarms",
+		Activate: func(storeType, storeName string, nic ifs.IVNic) {
+			services.ActivateAlmServices(storeType, storeName, nic)
+		}}, nic)
+}
+
+func startStore(nic ifs.IVNic, storeType, storeName string) (common.DataStore, error) {
+	store, err := common.NewDataStore(storeType, storeName, nic.Resources())
+	if err == nil {
+		err = store.Start()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %s", storeType, storeName, err.Error())
+	}
+	store.ReportHealth(nic)
+	return store, nil
+}
@@ -17,52 +17,112 @@ package commands
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/saichler/l8parser/go/parser/boot"
 	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
 	"github.com/saichler/l8types/go/ifs"
 	"github.com/saichler/l8web/go/web/client"
-	"google.golang.org/protobuf/encoding/protojson"
+	"github.com/saichler/probler/go/prob/common"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-func Details(rc *client.RestClient, resources ifs.IResources) {
-	resources.Registry().Register(&l8tpollaris.CJob{})
-
-	job := boot.NodeDetailsJob("lab", "lab", "node4")
-	doCJob("Node Detail", rc, job)
-
-	job = boot.PodDetailsJob("lab", "lab", "probler-k8s", "probler-k8s-0")
-	doCJob("Pods Detail", rc, job)
-
-	job = boot.DeploymentDetailsJob("lab", "lab", "probler-parser", "probler-parser")
-	doCJob("Deployment Detail", rc, job)
-
-	job = boot.StatefulsetDetailsJob("lab", "lab", "probler-k8s", "probler-k8s")
-	doCJob("Statefulset Detail", rc, job)
-
-	job = boot.DaemonsetDetailsJob("lab", "lab", "probler-vnet", "probler-vnet")
-	doCJob("Daemonset Detail", rc, job)
+// describeJobs maps the links id of a K8s kind to the boot job that describes
+// one object of it, kubectl describe style. Kinds without a job are described
+// from their inventory cache.
+var describeJobs = map[string]func(cluster, namespace, name string) *l8tpollaris.CJob{
+	common.K8sNode_Links_ID: func(c, ns, n string) *l8tpollaris.CJob {
+		return boot.NodeDetailsJob(c, c, n)
+	},
+	common.K8sNs_Links_ID: func(c, ns, n string) *l8tpollaris.CJob {
+		return boot.NamespaceDetailsJob(c, c, n)
+	},
+	common.K8sPod_Links_ID: func(c, ns, n string) *l8tpollaris.CJob {
+		return boot.PodDetailsJob(c, c, ns, n)
+	},
+	common.K8sDeploy_Links_ID: func(c, ns, n string) *l8tpollaris.CJob {
+		return boot.DeploymentDetailsJob(c, c, ns, n)
+	},
+	common.K8sSts_Links_ID: func(c, ns, n string) *l8tpollaris.CJob {
+		return boot.StatefulsetDetailsJob(c, c, ns, n)
+	},
+	common.K8sDs_Links_ID: func(c, ns, n string) *l8tpollaris.CJob {
+		return boot.DaemonsetDetailsJob(c, c, ns, n)
+	},
+	common.K8sSvc_Links_ID: func(c, ns, n string) *l8tpollaris.CJob {
+		return boot.ServiceDetailsJob(c, c, ns, n)
+	},
+	common.K8sNetPol_Links_ID: func(c, ns, n string) *l8tpollaris.CJob {
+		return boot.NetworkPolicyDetailsJob(c, c, ns, n)
+	},
+}
 
-	job = boot.ServiceDetailsJob("lab", "lab", "kube-system", "kube-dns")
-	doCJob("Service Detail", rc, job)
+// DescribeKinds lists the kinds Describe supports, which is every K8s kind.
+func DescribeKinds() []string {
+	names := make([]string, 0, len(K8sKinds))
+	for _, kind := range K8sKinds {
+		names = append(names, kind.Name)
+	}
+	sort.Strings(names)
+	return names
+}
 
-	job = boot.NamespaceDetailsJob("lab", "lab", "kube-system")
-	doCJob("Namespace Detail", rc, job)
+// Describe describes one object, given as <cluster>/<namespace>/<name> or
+// <cluster>/<name> for cluster scoped kinds. Kinds with a detail job return
+// its text output; any other kind returns the object from its inventory cache.
+func Describe(kind, path string, rc *client.RestClient, resources ifs.IResources) (string, proto.Message, error) {
+	k, ok := LookupK8sKind(kind)
+	if !ok {
+		return "", nil, fmt.Errorf("unknown kind %q, expected one of %s", kind, strings.Join(DescribeKinds(), ", "))
+	}
+	cluster, namespace, name, err := ParseObjectPath(path, k.Namespaced)
+	if err != nil {
+		return "", nil, err
+	}
+	if details, ok := describeJobs[k.LinksId]; ok {
+		resources.Registry().Register(&l8tpollaris.CJob{})
+		job, err := DoCJob("describe "+kind+" "+path, rc, details(cluster, namespace, name))
+		if err != nil {
+			return "", nil, err
+		}
+		return string(job.Result), nil, nil
+	}
+	list, err := GetK8s(k, cluster, namespace, "Name="+name, rc, resources)
+	if err != nil {
+		return "", nil, err
+	}
+	obj := firstElement(list)
+	if obj == nil {
+		return "", nil, fmt.Errorf("%s %s not found", k.Name, path)
+	}
+	return "", obj, nil
+}
 
-	job = boot.NetworkPolicyDetailsJob("lab", "lab", "default", "access-nginx")
-	doCJob("Network Policy Detail", rc, job)
+// firstElement returns the first element of a list message, i.e. of its first
+// repeated message field, or nil when the list is empty.
+func firstElement(list proto.Message) proto.Message {
+	var first proto.Message
+	list.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.IsList() && fd.Message() != nil && v.List().Len() > 0 {
+			first = v.List().Get(0).Message().Interface()
+			return false
+		}
+		return true
+	})
+	return first
 }
 
-func doCJob(name string, rc *client.RestClient, job *l8tpollaris.CJob) {
-	jsn, err := protojson.Marshal(job)
-	fmt.Println("body for ", name, ":", string(jsn))
+// DoCJob posts a CJob to the exec service and returns the executed job.
+func DoCJob(name string, rc *client.RestClient, job *l8tpollaris.CJob) (*l8tpollaris.CJob, error) {
 	resp, err := rc.POST("0/exec", "CJob", "", "", job)
 	if err != nil {
-		fmt.Println("POST Error:", err.Error())
-		return
+		return nil, fmt.Errorf("%s: %s", name, err.Error())
 	}
-	jsn, err = protojson.Marshal(resp)
-	if err != nil {
-		fmt.Println("Error:", err.Error())
+	result, ok := resp.(*l8tpollaris.CJob)
+	if !ok {
+		return nil, fmt.Errorf("%s: unexpected response type %T", name, resp)
 	}
+	return result, nil
 }
@@ -0,0 +1,46 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8web/go/web/client"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Exec loads a CJob from a protojson file, runs it through the exec service
+// and prints the job result.
+func Exec(filename string, rc *client.RestClient, resources ifs.IResources) error {
+	resources.Registry().Register(&l8tpollaris.CJob{})
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	job := &l8tpollaris.CJob{}
+	if err = protojson.Unmarshal(data, job); err != nil {
+		return fmt.Errorf("failed to parse %s: %s", filename, err.Error())
+	}
+	result, err := DoCJob(filename, rc, job)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(result.Result))
+	return nil
+}
@@ -16,26 +16,87 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/saichler/l8parser/go/parser/boot"
 	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
 	"github.com/saichler/l8types/go/ifs"
 	"github.com/saichler/l8web/go/web/client"
-	"google.golang.org/protobuf/encoding/protojson"
 )
 
-func Logs(rc *client.RestClient, namespace, podname string, resources ifs.IResources) {
+// LogsOptions narrows the fetched logs. The collector's logs job takes no
+// arguments: it always fetches the whole log of the pod. Tail is applied to
+// its output; Container and Since cannot be, and are refused.
+type LogsOptions struct {
+	Container string
+	Tail      int
+	Since     string
+}
+
+// errLogsOption is returned for the options the logs job cannot support.
+var errLogsOption = errors.New("--container and --since are not supported: the collector's logs job takes no arguments and fetches the whole log of the pod")
+
+// Validate refuses the options the logs job cannot support.
+func (this *LogsOptions) Validate() error {
+	if this.Container != "" || this.Since != "" {
+		return errLogsOption
+	}
+	if this.Tail < 0 {
+		return errors.New("tail must not be negative")
+	}
+	return nil
+}
+
+// ParseObjectPath splits a <cluster>/<namespace>/<name> path, or a
+// <cluster>/<name> path when namespaced is false.
+func ParseObjectPath(path string, namespaced bool) (cluster, namespace, name string, err error) {
+	parts := strings.Split(path, "/")
+	if namespaced {
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return "", "", "", fmt.Errorf("invalid path %q, expected <cluster>/<namespace>/<name>", path)
+		}
+		return parts[0], parts[1], parts[2], nil
+	}
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("invalid path %q, expected <cluster>/<name>", path)
+	}
+	return parts[0], "", parts[1], nil
+}
+
+// Logs fetches the logs of a pod given as <cluster>/<namespace>/<pod>.
+func Logs(rc *client.RestClient, path string, opts *LogsOptions, resources ifs.IResources) (string, error) {
+	cluster, namespace, pod, err := ParseObjectPath(path, true)
+	if err != nil {
+		return "", err
+	}
+	if opts == nil {
+		opts = &LogsOptions{}
+	}
+	if err = opts.Validate(); err != nil {
+		return "", err
+	}
 	resources.Registry().Register(&l8tpollaris.CJob{})
-	job := boot.LogsJob("lab", "lab", "probler-collector", "probler-collector-0")
-	jsn, err := protojson.Marshal(job)
-	fmt.Println("body:", string(jsn))
-	resp, err := rc.POST("0/exec", "CJob", "", "", job)
+	// The cluster target has a single host named after it, see CreateCluster.
+	job, err := DoCJob("logs "+path, rc, boot.LogsJob(cluster, cluster, namespace, pod))
 	if err != nil {
-		resources.Logger().Error("POST Error:", err.Error())
-		return
+		return "", err
 	}
+	return tailLines(string(job.Result), opts.Tail), nil
+}
 
-	job = resp.(*l8tpollaris.CJob)
-	fmt.Println(string(job.Result))
+// tailLines returns the last n lines of logs, or all of them when n is 0.
+func tailLines(logs string, n int) string {
+	if n <= 0 {
+		return logs
+	}
+	lines := strings.SplitAfter(logs, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= n {
+		return logs
+	}
+	return strings.Join(lines[len(lines)-n:], "")
 }
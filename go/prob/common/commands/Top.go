@@ -18,59 +18,194 @@ package commands
 import (
 	"bytes"
 	"fmt"
+	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	health2 "github.com/saichler/l8bus/go/overlay/health"
 	"github.com/saichler/l8types/go/ifs"
 	"github.com/saichler/l8types/go/types/l8health"
 	"github.com/saichler/l8web/go/web/client"
-	health2 "github.com/saichler/l8bus/go/overlay/health"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 )
 
-func Top(rc *client.RestClient, resources ifs.IResources) {
-	defer time.Sleep(time.Second)
+func Top(rc *client.RestClient, resources ifs.IResources) (*l8health.L8Top, error) {
 	health := &l8health.L8Health{}
 	resp, err := rc.GET("0/"+health2.ServiceName, "Top",
 		"", "", health)
 	if err != nil {
-		resources.Logger().Error(err.Error())
-		return
+		return nil, err
 	}
 	top, ok := resp.(*l8health.L8Top)
-	if ok {
-		fmt.Println(FormatTop(top))
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
 	}
+	return top, nil
 }
 
-func buildTop(top *l8health.L8Top) string {
+// Top sort keys.
+const (
+	TopSortTx    = "tx"
+	TopSortRx    = "rx"
+	TopSortMem   = "mem"
+	TopSortCpu   = "cpu"
+	TopSortAlias = "alias"
+)
+
+// TopView renders successive L8Top snapshots like unix top. It remembers the
+// previous snapshot so the Tx/s and Rx/s columns show the message rate since
+// the last refresh, and sorting by tx or rx uses that rate once it is known.
+type TopView struct {
+	sortBy   string
+	filter   *regexp.Regexp
+	prev     map[string]*topCounters
+	prevTime time.Time
+}
 
-	points := make([]*l8health.L8Health, 0)
-	for _, hp := range top.Healths {
-		points = append(points, hp)
+type topCounters struct {
+	tx int64
+	rx int64
+}
+
+type topRates struct {
+	tx float64
+	rx float64
+}
+
+func NewTopView(sortBy, filter string) (*TopView, error) {
+	view := &TopView{sortBy: strings.ToLower(sortBy)}
+	switch view.sortBy {
+	case "":
+		view.sortBy = TopSortMem
+	case TopSortTx, TopSortRx, TopSortMem, TopSortCpu, TopSortAlias:
+	default:
+		return nil, fmt.Errorf("unknown sort key %q, expected tx, rx, mem, cpu or alias", sortBy)
+	}
+	if filter != "" {
+		re, err := regexp.Compile(filter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter: %s", err.Error())
+		}
+		view.filter = re
 	}
+	return view, nil
+}
 
-	sort.Slice(points, func(i, j int) bool {
-		if points[i].Stats == nil {
-			return false
-		} else if points[j].Stats == nil {
+// Filter returns a copy of top holding only the processes whose alias matches
+// the filter.
+func (this *TopView) Filter(top *l8health.L8Top) *l8health.L8Top {
+	if this.filter == nil || top == nil {
+		return top
+	}
+	filtered := &l8health.L8Top{Healths: make(map[string]*l8health.L8Health)}
+	for key, hp := range top.Healths {
+		if this.filter.MatchString(hp.Alias) {
+			filtered.Healths[key] = hp
+		}
+	}
+	return filtered
+}
+
+// Render formats a snapshot taken at now and records it for the next rates.
+func (this *TopView) Render(top *l8health.L8Top, now time.Time) string {
+	top = this.Filter(top)
+	rates := make(map[string]*topRates)
+	elapsed := now.Sub(this.prevTime).Seconds()
+	keys := make([]string, 0, len(top.Healths))
+	for key, hp := range top.Healths {
+		keys = append(keys, key)
+		prev, ok := this.prev[key]
+		// A counter that went backwards means the process restarted.
+		if ok && hp.Stats != nil && elapsed > 0 &&
+			hp.Stats.TxMsgCount >= prev.tx && hp.Stats.RxMsgCount >= prev.rx {
+			rates[key] = &topRates{
+				tx: float64(hp.Stats.TxMsgCount-prev.tx) / elapsed,
+				rx: float64(hp.Stats.RxMsgCount-prev.rx) / elapsed,
+			}
+		}
+	}
+	this.sort(keys, top, rates)
+
+	this.prev = make(map[string]*topCounters)
+	for key, hp := range top.Healths {
+		if hp.Stats != nil {
+			this.prev[key] = &topCounters{tx: hp.Stats.TxMsgCount, rx: hp.Stats.RxMsgCount}
+		}
+	}
+	this.prevTime = now
+	return buildTop(keys, top, rates)
+}
+
+func (this *TopView) sort(keys []string, top *l8health.L8Top, rates map[string]*topRates) {
+	less := func(a, b *l8health.L8Health, ra, rb *topRates) bool {
+		switch this.sortBy {
+		case TopSortAlias:
+			return a.Alias < b.Alias
+		case TopSortTx:
+			if ra != nil && rb != nil {
+				return ra.tx > rb.tx
+			}
+			// Rates and raw counters are not comparable, processes with no
+			// rate yet go after those that have one.
+			if ra != nil || rb != nil {
+				return ra != nil
+			}
+			return a.Stats.TxMsgCount > b.Stats.TxMsgCount
+		case TopSortRx:
+			if ra != nil && rb != nil {
+				return ra.rx > rb.rx
+			}
+			if ra != nil || rb != nil {
+				return ra != nil
+			}
+			return a.Stats.RxMsgCount > b.Stats.RxMsgCount
+		case TopSortCpu:
+			return a.Stats.CpuUsage > b.Stats.CpuUsage
+		}
+		return a.Stats.MemoryUsage > b.Stats.MemoryUsage
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		a := top.Healths[keys[i]]
+		b := top.Healths[keys[j]]
+		if this.sortBy != TopSortAlias {
+			if a.Stats == nil {
+				return false
+			} else if b.Stats == nil {
+				return true
+			}
+		}
+		if less(a, b, rates[keys[i]], rates[keys[j]]) {
 			return true
 		}
-		return points[i].Stats.MemoryUsage > points[j].Stats.MemoryUsage
+		if less(b, a, rates[keys[j]], rates[keys[i]]) {
+			return false
+		}
+		return keys[i] < keys[j]
 	})
+}
 
+func buildTop(keys []string, top *l8health.L8Top, rates map[string]*topRates) string {
 	alias := colOf("Alias")
 	tx := colOf("Tx Messages")
+	txRate := colOf("Tx/s")
 	rx := colOf("Rx Messages")
+	rxRate := colOf("Rx/s")
+	cpu := colOf("%CPU")
 	mem := colOf("Memory Usage")
 
-	for _, v := range points {
-		row := &Row{hp: v}
+	rows := make([]*Row, 0, len(keys))
+	for _, key := range keys {
+		row := &Row{hp: top.Healths[key], rates: rates[key]}
+		rows = append(rows, row)
 		alias.SetLen(row.Name())
 		tx.SetLen(row.Tx())
+		txRate.SetLen(row.TxRate())
 		rx.SetLen(row.Rx())
+		rxRate.SetLen(row.RxRate())
+		cpu.SetLen(row.Cpu())
 		mem.SetLen(row.Mem())
 	}
 
@@ -78,15 +213,21 @@ func buildTop(top *l8health.L8Top) string {
 	buff.WriteString(" ")
 	alias.writeString(alias.name, buff)
 	tx.writeString(tx.name, buff)
+	txRate.writeString(txRate.name, buff)
 	rx.writeString(rx.name, buff)
+	rxRate.writeString(rxRate.name, buff)
+	cpu.writeString(cpu.name, buff)
+	mem.writeString(mem.name, buff)
 	buff.WriteString("\n")
 
-	for _, v := range points {
-		row := &Row{hp: v}
+	for _, row := range rows {
 		buff.WriteString(" ")
 		alias.writeString(row.Name(), buff)
 		tx.writeNumber(row.Tx(), buff)
+		txRate.writeNumber(row.TxRate(), buff)
 		rx.writeNumber(row.Rx(), buff)
+		rxRate.writeNumber(row.RxRate(), buff)
+		cpu.writeNumber(row.Cpu(), buff)
 		mem.writeNumber(row.Mem(), buff)
 		buff.WriteString("\n")
 	}
@@ -126,7 +267,8 @@ func (this *Column) writeNumber(str string, buff *bytes.Buffer) {
 }
 
 type Row struct {
-	hp *l8health.L8Health
+	hp    *l8health.L8Health
+	rates *topRates
 }
 
 func (this *Row) Name() string {
@@ -147,6 +289,27 @@ func (this *Row) Rx() string {
 	return ""
 }
 
+func (this *Row) TxRate() string {
+	if this.rates != nil {
+		return strconv.FormatFloat(this.rates.tx, 'f', 1, 64)
+	}
+	return "-"
+}
+
+func (this *Row) RxRate() string {
+	if this.rates != nil {
+		return strconv.FormatFloat(this.rates.rx, 'f', 1, 64)
+	}
+	return "-"
+}
+
+func (this *Row) Cpu() string {
+	if this.hp.Stats != nil {
+		return strconv.FormatFloat(this.hp.Stats.CpuUsage, 'f', 1, 64)
+	}
+	return ""
+}
+
 func (this *Row) Mem() string {
 	if this.hp.Stats != nil {
 		return toMemory(this.hp.Stats.MemoryUsage)
@@ -16,6 +16,7 @@
 package commands
 
 import (
+	"fmt"
 	"github.com/saichler/l8pollaris/go/pollaris/targets"
 	"github.com/saichler/probler/go/prob/common/creates"
 	"time"
@@ -25,17 +26,18 @@ import (
 	"github.com/saichler/l8web/go/web/client"
 )
 
-func AddCluster(kubeadm, context string, rc *client.RestClient, resources common2.IResources) {
+func AddCluster(name string, rc *client.RestClient, resources common2.IResources) error {
 	defer time.Sleep(time.Second)
-	device := creates.CreateCluster("lab")
+	device := creates.CreateCluster(name)
 	resp, err := rc.POST("0/"+targets.ServiceName, "Device",
 		"", "", device)
 	if err != nil {
-		resources.Logger().Error(err.Error())
-		return
+		return err
 	}
-	_, ok := resp.(*l8tpollaris.L8Pollaris)
-	if ok {
-		resources.Logger().Info("Added ", device.TargetId, " Successfully")
+	_, ok := resp.(*l8tpollaris.L8PTarget)
+	if !ok {
+		return fmt.Errorf("unexpected response type %T", resp)
 	}
+	resources.Logger().Info("Added ", device.TargetId, " Successfully")
+	return nil
 }
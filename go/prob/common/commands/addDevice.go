@@ -16,6 +16,7 @@
 package commands
 
 import (
+	"fmt"
 	"github.com/saichler/l8pollaris/go/pollaris/targets"
 	"github.com/saichler/probler/go/prob/common"
 	"time"
@@ -26,17 +27,18 @@ import (
 	"github.com/saichler/probler/go/prob/common/creates"
 )
 
-func AddDevice(ip string, rc *client.RestClient, resources common2.IResources) {
+func AddDevice(ip string, rc *client.RestClient, resources common2.IResources) error {
 	defer time.Sleep(time.Second)
 	device := creates.CreateDevice(ip, common.NetworkDevice_Links_ID, "sim")
 	resp, err := rc.POST("0/"+targets.ServiceName, "Device",
 		"", "", device)
 	if err != nil {
-		resources.Logger().Error(err.Error())
-		return
+		return err
 	}
 	_, ok := resp.(*l8tpollaris.L8PTarget)
-	if ok {
-		resources.Logger().Info("Added ", device.TargetId, " Successfully")
+	if !ok {
+		return fmt.Errorf("unexpected response type %T", resp)
 	}
+	resources.Logger().Info("Added ", device.TargetId, " Successfully")
+	return nil
 }
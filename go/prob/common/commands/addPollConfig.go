@@ -26,15 +26,14 @@ import (
 	"github.com/saichler/l8web/go/web/client"
 )
 
-func AddPollConfigs(rc *client.RestClient, resources common2.IResources) {
+func AddPollConfigs(rc *client.RestClient, resources common2.IResources) error {
 	snmpPollarises := boot.GetAllPolarisModels()
 	for _, snmpPollaris := range snmpPollarises {
 		resp, err := rc.POST(strconv.Itoa(int(pollaris.ServiceArea))+"/"+pollaris.ServiceName,
 			"Pollaris", "", "", snmpPollaris)
 
 		if err != nil {
-			resources.Logger().Error(err.Error())
-			return
+			return err
 		}
 		_, ok := resp.(*l8tpollaris.L8Pollaris)
 		if ok {
@@ -48,13 +47,12 @@ func AddPollConfigs(rc *client.RestClient, resources common2.IResources) {
 		"Pollaris", "", "", k8sPollaris)
 
 	if err != nil {
-		resources.Logger().Error(err.Error())
-		return
+		return err
 	}
 	_, ok := resp.(*l8tpollaris.L8Pollaris)
 	if ok {
 		resources.Logger().Info("Added ", k8sPollaris.Name, " Successfully")
 	}
 	time.Sleep(time.Second)
-
+	return nil
 }
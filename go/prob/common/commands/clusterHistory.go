@@ -0,0 +1,95 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/saichler/l8web/go/web/client"
+	"github.com/saichler/probler/go/prob/common"
+	types2 "github.com/saichler/probler/go/types"
+)
+
+// GetClusterHistory queries the cluster history service for the summary
+// history of a cluster. No metrics means all of them.
+func GetClusterHistory(rc *client.RestClient, cluster string, from, to time.Time, metrics []string) (*types2.ClusterSeries, error) {
+	q := &types2.ClusterHistoryQuery{Cluster: cluster, From: from.Unix(), To: to.Unix(), Metrics: metrics}
+	resp, err := rc.POST(strconv.Itoa(int(common.ClusterHistory_Service_Area))+"/"+common.ClusterHistory_Service_Name,
+		"ClusterSeries", "", "", q)
+	if err != nil {
+		return nil, err
+	}
+	series, ok := resp.(*types2.ClusterSeries)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
+	}
+	return series, nil
+}
+
+// ClusterHistoryRows lays a history out one row per stamp and one column per
+// metric. Rolled up points show min/avg/max when wide.
+func ClusterHistoryRows(series *types2.ClusterSeries, wide bool) ([]string, [][]string) {
+	header := []string{"Time"}
+	byStamp := make(map[int64][]string)
+	for _, m := range common.ClusterMetrics {
+		points, ok := series.Metrics[m.Name]
+		if !ok {
+			continue
+		}
+		col := len(header) - 1
+		header = append(header, m.Name)
+		for _, p := range points.Points {
+			row := byStamp[p.Stamp]
+			for len(row) <= col {
+				row = append(row, "")
+			}
+			row[col] = formatPoint(p, wide && series.StepSeconds > 0)
+			byStamp[p.Stamp] = row
+		}
+	}
+	stamps := make([]int64, 0, len(byStamp))
+	for stamp := range byStamp {
+		stamps = append(stamps, stamp)
+	}
+	sort.Slice(stamps, func(i, j int) bool {
+		return stamps[i] < stamps[j]
+	})
+	rows := make([][]string, 0, len(stamps))
+	for _, stamp := range stamps {
+		row := append([]string{time.Unix(stamp, 0).Format(time.RFC3339)}, byStamp[stamp]...)
+		for len(row) < len(header) {
+			row = append(row, "")
+		}
+		rows = append(rows, row)
+	}
+	return header, rows
+}
+
+func formatPoint(p *types2.SeriesPoint, minMax bool) string {
+	if !minMax {
+		return formatValue(p.Value)
+	}
+	return formatValue(p.Min) + "/" + formatValue(p.Value) + "/" + formatValue(p.Max)
+}
+
+// formatValue prints counts as integers and averages with two decimals.
+func formatValue(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,92 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8web/go/web/client"
+	"github.com/saichler/probler/go/prob/common"
+	types2 "github.com/saichler/probler/go/types"
+)
+
+// Debug dump kinds.
+const (
+	DumpGoroutines = "goroutines"
+	DumpHeap       = "heap"
+)
+
+// Debug posts a control to the debug service, which forwards it to the process
+// of ctl.Alias, or to every process when the alias is empty, and returns one
+// reply per process. A control one process refused is an error.
+func Debug(ctl *types2.DebugControl, rc *client.RestClient, resources ifs.IResources) ([]*types2.DebugReply, error) {
+	common.RegisterDebugTypes(resources)
+	resp, err := rc.POST(strconv.Itoa(int(common.Debug_Service_Area))+"/"+common.Debug_Service_Name,
+		"DebugReplyList", "", "", ctl)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := resp.(*types2.DebugReplyList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
+	}
+	for _, reply := range list.List {
+		if reply.Error != "" {
+			return list.List, fmt.Errorf("%s: %s", reply.Alias, reply.Error)
+		}
+	}
+	return list.List, nil
+}
+
+// DebugLogLevel is the control setting the log level of a process, or of one
+// component of it.
+func DebugLogLevel(alias, component, level string) (*types2.DebugControl, error) {
+	if _, err := common.ParseLogLevel(level); err != nil {
+		return nil, err
+	}
+	return &types2.DebugControl{Alias: alias, Action: types2.DebugAction_DEBUG_ACTION_LOG_LEVEL,
+		Component: component, Level: level}, nil
+}
+
+// DebugTrace is the control turning the routing trace of a links id on or off.
+// No links id lists the traced ones.
+func DebugTrace(alias, linkid string, off bool) (*types2.DebugControl, error) {
+	if off && linkid == "" {
+		return nil, fmt.Errorf("no links id to stop tracing")
+	}
+	action := types2.DebugAction_DEBUG_ACTION_TRACE
+	if off {
+		action = types2.DebugAction_DEBUG_ACTION_UNTRACE
+	}
+	return &types2.DebugControl{Alias: alias, Action: action, LinksId: linkid}, nil
+}
+
+// DebugDump is the control fetching a goroutine dump or a heap profile of one
+// process.
+func DebugDump(alias, kind string) (*types2.DebugControl, error) {
+	if alias == "" {
+		return nil, fmt.Errorf("a dump is of one process, no alias given")
+	}
+	switch kind {
+	case DumpGoroutines:
+		return &types2.DebugControl{Alias: alias, Action: types2.DebugAction_DEBUG_ACTION_DUMP_GOROUTINES}, nil
+	case DumpHeap:
+		return &types2.DebugControl{Alias: alias, Action: types2.DebugAction_DEBUG_ACTION_DUMP_HEAP}, nil
+	}
+	return nil, fmt.Errorf("unknown dump %q, expected %s or %s", kind, DumpGoroutines, DumpHeap)
+}
@@ -0,0 +1,35 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"github.com/saichler/l8pollaris/go/pollaris/targets"
+
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+	common2 "github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8web/go/web/client"
+)
+
+func DeleteTarget(targetId string, rc *client.RestClient, resources common2.IResources) error {
+	target := &l8tpollaris.L8PTarget{TargetId: targetId}
+	_, err := rc.DELETE("0/"+targets.ServiceName, "L8PTarget",
+		"", "", target)
+	if err != nil {
+		return err
+	}
+	resources.Logger().Info("Deleted ", targetId, " Successfully")
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+	common2 "github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8web/go/web/client"
+	"github.com/saichler/probler/go/prob/common/creates"
+	"sigs.k8s.io/yaml"
+)
+
+// LoadFleetSpec reads a YAML or JSON fleet spec:
+//
+//	name: scale-lab
+//	seed: 42
+//	credId: sim
+//	groups:
+//	  - type: switch
+//	    count: 2000
+//	    pool: 60.50.40.1/16
+//	    protocols: {snmp: 1, ssh: 0.3}
+//	  - type: router
+//	    count: 500
+//	    pool: 60.60.0.0/16
+//	  - type: gpu
+//	    count: 100
+//	    pool: 20.20.10.1/16
+//	  - type: cluster
+//	    count: 2
+func LoadFleetSpec(filename string) (*creates.FleetSpec, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	spec := &creates.FleetSpec{}
+	if err = yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", filename, err.Error())
+	}
+	if spec.Name == "" {
+		return nil, fmt.Errorf("%s: fleet spec has no name", filename)
+	}
+	return spec, nil
+}
+
+// FleetUp generates the fleet and posts its targets in chunks. With dryRun the
+// generated targets are only summarized.
+func FleetUp(spec *creates.FleetSpec, chunk int, dryRun bool, rc *client.RestClient, resources common2.IResources) error {
+	fleet, err := creates.CreateFleet(spec)
+	if err != nil {
+		return err
+	}
+	printFleet(spec, fleet)
+	if dryRun {
+		return nil
+	}
+	if failed := postTargets(fleet, chunk, rc); failed > 0 {
+		return fmt.Errorf("%d of %d targets failed to post", failed, len(fleet))
+	}
+	return nil
+}
+
+// FleetDown regenerates the fleet and deletes every one of its targets. Since
+// generation is deterministic this removes exactly what FleetUp added.
+func FleetDown(spec *creates.FleetSpec, rc *client.RestClient, resources common2.IResources) error {
+	fleet, err := creates.CreateFleet(spec)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, t := range fleet {
+		if err = DeleteTarget(t.TargetId, rc, resources); err != nil {
+			fmt.Println(t.TargetId, "delete failed:", err.Error())
+			failed++
+		}
+	}
+	fmt.Println("Deleted", len(fleet)-failed, "of", len(fleet), "targets of fleet", spec.Name)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d targets failed to delete", failed, len(fleet))
+	}
+	return nil
+}
+
+// FleetTargets generates the fleet without posting it.
+func FleetTargets(spec *creates.FleetSpec) (*l8tpollaris.L8PTargetList, error) {
+	fleet, err := creates.CreateFleet(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &l8tpollaris.L8PTargetList{List: fleet}, nil
+}
+
+func printFleet(spec *creates.FleetSpec, fleet []*l8tpollaris.L8PTarget) {
+	byLinks := make(map[string]int)
+	for _, t := range fleet {
+		byLinks[t.LinksId]++
+	}
+	linksIds := make([]string, 0, len(byLinks))
+	for linksId := range byLinks {
+		linksIds = append(linksIds, linksId)
+	}
+	sort.Strings(linksIds)
+	fmt.Println("Fleet", spec.Name, "seed", spec.Seed, "has", len(fleet), "targets")
+	for _, linksId := range linksIds {
+		fmt.Println("  ", linksId, byLinks[linksId])
+	}
+}
@@ -17,62 +17,55 @@ package commands
 
 import (
 	"fmt"
-	"github.com/saichler/l8pollaris/go/pollaris/targets"
-	"time"
 
+	"github.com/saichler/l8pollaris/go/pollaris/targets"
 	"github.com/saichler/l8srlz/go/serialize/object"
 	common2 "github.com/saichler/l8types/go/ifs"
 	"github.com/saichler/l8web/go/web/client"
 	"github.com/saichler/probler/go/prob/common"
-	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
-func GetCluster(rc *client.RestClient, resources common2.IResources, name string) {
-	defer time.Sleep(time.Second)
+func GetCluster(rc *client.RestClient, resources common2.IResources, name string) (proto.Message, error) {
 	elems, e := object.NewQuery("select * from k8scluster where Name="+name, resources)
-	q := elems.(*object.Elements)
-	pq := q.PQuery()
-
-	jsn, _ := protojson.Marshal(pq)
-	fmt.Println(string(jsn))
-
 	if e != nil {
-		fmt.Println("Error: ", e.Error())
-		return
+		return nil, e
 	}
+	q := elems.(*object.Elements)
+	pq := q.PQuery()
 
 	cs, _ := targets.Links.Cache(common.K8sClust_Links_ID)
 
 	resp, err := rc.GET("1/"+cs, "K8SClusterList",
 		"", "", pq)
 	if err != nil {
-		resources.Logger().Error("Get Error:", err.Error())
-		return
+		return nil, err
 	}
-	fmt.Println(resp)
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
+	}
+	return msg, nil
 }
 
-func GetClusterOrm(rc *client.RestClient, resources common2.IResources, name string) {
-	defer time.Sleep(time.Second)
+func GetClusterOrm(rc *client.RestClient, resources common2.IResources, name string) (proto.Message, error) {
 	elems, e := object.NewQuery("select * from k8scluster where Name="+name, resources)
-	q := elems.(*object.Elements)
-	pq := q.PQuery()
-
-	jsn, _ := protojson.Marshal(pq)
-	fmt.Println(string(jsn))
-
 	if e != nil {
-		fmt.Println("Error: ", e.Error())
-		return
+		return nil, e
 	}
+	q := elems.(*object.Elements)
+	pq := q.PQuery()
 
 	cs, _ := targets.Links.Cache(common.K8sClust_Links_ID)
 
 	resp, err := rc.GET("1/"+cs, "K8SCluster",
 		"", "", pq)
 	if err != nil {
-		resources.Logger().Error("Get Error:", err.Error())
-		return
+		return nil, err
+	}
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
 	}
-	fmt.Println(resp)
+	return msg, nil
 }
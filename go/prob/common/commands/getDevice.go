@@ -17,42 +17,33 @@ package commands
 
 import (
 	"fmt"
-	"github.com/saichler/l8pollaris/go/pollaris/targets"
-	"time"
 
+	"github.com/saichler/l8pollaris/go/pollaris/targets"
 	"github.com/saichler/l8srlz/go/serialize/object"
 	common2 "github.com/saichler/l8types/go/ifs"
 	"github.com/saichler/l8web/go/web/client"
 	"github.com/saichler/probler/go/prob/common"
-	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
 
-func GetDevice(rc *client.RestClient, resources common2.IResources, ip string) {
-	defer time.Sleep(time.Second)
+func GetDevice(rc *client.RestClient, resources common2.IResources, ip string) (proto.Message, error) {
 	elems, e := object.NewQuery("select * from NetworkDevice where Id="+ip, resources)
-	q := elems.(*object.Elements)
-	pq := q.PQuery()
-
 	if e != nil {
-		fmt.Println("Error: ", e.Error())
-		return
+		return nil, e
 	}
-	jsn, err := protojson.Marshal(pq)
-	fmt.Println(string(jsn))
+	q := elems.(*object.Elements)
+	pq := q.PQuery()
 
 	cs, _ := targets.Links.Cache(common.NetworkDevice_Links_ID)
 
 	resp, err := rc.GET("0/"+cs, "NetworkDeviceList",
 		"", "", pq)
 	if err != nil {
-		resources.Logger().Error("Get Error:", err.Error())
-		return
+		return nil, err
 	}
-	jsn, err = protojson.Marshal(resp.(proto.Message))
-	if err != nil {
-		fmt.Println(err)
-		return
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
 	}
-	fmt.Println(string(jsn))
+	return msg, nil
 }
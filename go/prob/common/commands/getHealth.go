@@ -17,37 +17,28 @@ package commands
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/saichler/l8srlz/go/serialize/object"
 	common2 "github.com/saichler/l8types/go/ifs"
 	"github.com/saichler/l8web/go/web/client"
-	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
 
-func GetHealth(rc *client.RestClient, resources common2.IResources) {
-	defer time.Sleep(time.Second)
+func GetHealth(rc *client.RestClient, resources common2.IResources) (proto.Message, error) {
 	elems, e := object.NewQuery("select * from L8Health ", resources)
-	q := elems.(*object.Elements)
-	pq := q.PQuery()
-
 	if e != nil {
-		fmt.Println("Error: ", e.Error())
-		return
+		return nil, e
 	}
-	jsn, err := protojson.Marshal(pq)
-	fmt.Println(string(jsn))
+	q := elems.(*object.Elements)
+	pq := q.PQuery()
 
 	resp, err := rc.GET("0/Health", "L8HealthList", "", "", pq)
 	if err != nil {
-		resources.Logger().Error("Get Error:", err.Error())
-		return
+		return nil, err
 	}
-	jsn, err = protojson.Marshal(resp.(proto.Message))
-	if err != nil {
-		fmt.Println(err)
-		return
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
 	}
-	fmt.Println(string(jsn))
+	return msg, nil
 }
@@ -0,0 +1,122 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/saichler/l8pollaris/go/pollaris/targets"
+	"github.com/saichler/l8srlz/go/serialize/object"
+	common2 "github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8web/go/web/client"
+	"github.com/saichler/probler/go/prob/common"
+	"google.golang.org/protobuf/proto"
+)
+
+// K8sKind maps a prctl resource name to the K8s/Istio prime object it reads.
+// Namespaced is false for cluster-scoped kinds, which have no Namespace field.
+type K8sKind struct {
+	Name       string
+	Aliases    []string
+	LinksId    string
+	List       proto.Message
+	Namespaced bool
+}
+
+// K8sKinds is built from common.K8sPrimeObjects, every prime object with a
+// prctl name is a kind.
+var K8sKinds = buildK8sKinds()
+
+func buildK8sKinds() []*K8sKind {
+	kinds := make([]*K8sKind, 0, len(common.K8sPrimeObjects))
+	for _, po := range common.K8sPrimeObjects {
+		if po.Name == "" {
+			continue
+		}
+		kinds = append(kinds, &K8sKind{Name: po.Name, Aliases: po.Aliases, LinksId: po.LinksId,
+			List: po.List, Namespaced: po.Namespaced})
+	}
+	return kinds
+}
+
+// LookupK8sKind finds a kind by its name or one of its aliases.
+func LookupK8sKind(name string) (*K8sKind, bool) {
+	name = strings.ToLower(name)
+	for _, kind := range K8sKinds {
+		if kind.Name == name {
+			return kind, true
+		}
+		for _, alias := range kind.Aliases {
+			if alias == name {
+				return kind, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// K8sQuery builds the L8QL select for a prime object model, narrowed by cluster,
+// namespace and an optional free-form where expression.
+func K8sQuery(model, cluster, namespace, where string) string {
+	conditions := make([]string, 0, 3)
+	if cluster != "" {
+		conditions = append(conditions, "ClusterName="+cluster)
+	}
+	if namespace != "" {
+		conditions = append(conditions, "Namespace="+namespace)
+	}
+	if where = strings.TrimSpace(where); where != "" {
+		conditions = append(conditions, "("+where+")")
+	}
+	query := "select * from " + model
+	if len(conditions) > 0 {
+		query += " where " + strings.Join(conditions, " and ")
+	}
+	return query
+}
+
+// GetK8s fetches the list of a K8s prime object from its inventory cache.
+func GetK8s(kind *K8sKind, cluster, namespace, where string, rc *client.RestClient, resources common2.IResources) (proto.Message, error) {
+	if namespace != "" && !kind.Namespaced {
+		return nil, fmt.Errorf("%s are cluster scoped, --namespace does not apply", kind.Name)
+	}
+	model := targets.Links.Model(kind.LinksId)
+	cacheName, cacheArea := targets.Links.Cache(kind.LinksId)
+	if model == "" || cacheName == "" {
+		return nil, fmt.Errorf("no model or cache for links id %s", kind.LinksId)
+	}
+
+	resources.Registry().Register(kind.List)
+	elems, e := object.NewQuery(K8sQuery(model, cluster, namespace, where), resources)
+	if e != nil {
+		return nil, e
+	}
+	pq := elems.(*object.Elements).PQuery()
+
+	listType := string(kind.List.ProtoReflect().Descriptor().Name())
+	resp, err := rc.GET(strconv.Itoa(int(cacheArea))+"/"+cacheName, listType,
+		"", "", pq)
+	if err != nil {
+		return nil, err
+	}
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
+	}
+	return msg, nil
+}
@@ -0,0 +1,87 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8web/go/web/client"
+	"github.com/saichler/probler/go/prob/common"
+	types2 "github.com/saichler/probler/go/types"
+)
+
+// GetLinks queries the links service for the routes of the given links ids. No
+// ids means every links id.
+func GetLinks(linkids []string, rc *client.RestClient, resources ifs.IResources) ([]*types2.LinkRoute, error) {
+	q := "select * from LinkRoute"
+	for i, linkid := range linkids {
+		if i == 0 {
+			q += " where "
+		} else {
+			q += " or "
+		}
+		q += "LinksId=" + linkid
+	}
+	elems, err := object.NewQuery(q, resources)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := rc.GET(strconv.Itoa(int(common.Links_Service_Area))+"/"+common.Links_Service_Name,
+		"LinkRouteList", "", "", elems.(*object.Elements).PQuery())
+	if err != nil {
+		return nil, err
+	}
+	list, ok := resp.(*types2.LinkRouteList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
+	}
+	found := make(map[string]bool, len(list.List))
+	for _, route := range list.List {
+		found[route.LinksId] = true
+	}
+	for _, linkid := range linkids {
+		if !found[linkid] {
+			return nil, fmt.Errorf("unknown links id %q", linkid)
+		}
+	}
+	return list.List, nil
+}
+
+// LinksRows flattens the routes to one row per role for table and csv output.
+// Wide adds the hosting process aliases.
+func LinksRows(routes []*types2.LinkRoute, wide bool) ([]string, [][]string) {
+	header := []string{"LinksId", "Model", "Role", "Service", "Replicas", "Up"}
+	if wide {
+		header = append(header, "Hosts")
+	}
+	rows := make([][]string, 0, len(routes)*4)
+	for _, route := range routes {
+		for _, role := range route.Roles {
+			row := []string{route.LinksId, route.Model, role.Role,
+				role.Service + "/" + strconv.Itoa(int(role.Area)),
+				strconv.Itoa(int(role.Replicas)), strconv.Itoa(int(role.Up))}
+			if wide {
+				row = append(row, strings.Join(role.Hosts, ","))
+			}
+			rows = append(rows, row)
+		}
+	}
+	return header, rows
+}
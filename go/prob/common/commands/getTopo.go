@@ -15,38 +15,203 @@
 
 package commands
 
-/*
 import (
+	"bytes"
+	"encoding/xml"
+	"errors"
 	"fmt"
-	"os"
-	"time"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8topology/go/types/l8topo"
 	common2 "github.com/saichler/l8types/go/ifs"
-	"github.com/saichler/l8types/go/types/l8web"
 	"github.com/saichler/l8web/go/web/client"
-	"github.com/saichler/probler/go/prob/topology/service"
 	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/proto"
 )
 
-func GetTopo(cmd string, rc *client.RestClient, resources common2.IResources) {
-	defer time.Sleep(time.Second)
+// topoListService is the topology catalog activated by topo_list in
+// prob/topology. Every entry names the service and area serving one topology.
+const topoListService = "TopoList"
+
+// Topology export formats.
+const (
+	TopoDot     = "dot"
+	TopoGraphML = "graphml"
+	TopoJSON    = "json"
+)
+
+// ListTopologies returns the topologies registered with the topology catalog.
+func ListTopologies(rc *client.RestClient, resources common2.IResources) (*l8topo.L8TopologyMetadataList, error) {
+	resources.Registry().Register(&l8topo.L8TopologyMetadataList{})
+	elems, e := object.NewQuery("select * from l8topologymetadata", resources)
+	if e != nil {
+		return nil, e
+	}
+	resp, err := rc.GET("0/"+topoListService, "L8TopologyMetadataList", "", "", elems.(*object.Elements).PQuery())
+	if err != nil {
+		return nil, err
+	}
+	list, ok := resp.(*l8topo.L8TopologyMetadataList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
+	}
+	return list, nil
+}
 
-	if cmd != "" {
-		rc.PUT("0/"+service.ServiceName, "Empty", "", "", &l8web.L8Empty{})
-		return
+// GetTopo fetches the topology of a layer (l1, l2, l3 or k8s) or with a
+// catalog name. The layer is matched against the catalog names, e.g. l1
+// selects Network-L1.
+func GetTopo(layer string, rc *client.RestClient, resources common2.IResources) (*l8topo.L8Topology, error) {
+	layer = strings.ToLower(strings.TrimSpace(layer))
+	if layer == "" {
+		return nil, errors.New("no topology type given")
+	}
+	list, err := ListTopologies(rc, resources)
+	if err != nil {
+		return nil, err
+	}
+	var match *l8topo.L8TopologyMetadata
+	names := make([]string, 0, len(list.List))
+	for _, md := range list.List {
+		names = append(names, md.Name)
+		name := strings.ToLower(md.Name)
+		if name == layer || strings.HasSuffix(name, "-"+layer) || strings.HasPrefix(name, layer+"-") {
+			match = md
+			break
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no %s topology, available: %s", layer, strings.Join(names, ", "))
 	}
 
-	resp, err := rc.GET("0/"+service.ServiceName, "NetworkTopology", "", "", &l8web.L8Empty{})
+	resources.Registry().Register(&l8topo.L8Topology{})
+	resources.Registry().Register(&l8topo.L8TopologyQuery{})
+	path := fmt.Sprint(match.ServiceArea) + "/" + match.ServiceName
+	resp, err := rc.GET(path, "L8Topology", "", "", &l8topo.L8TopologyQuery{})
 	if err != nil {
-		resources.Logger().Error("Get Error:", err.Error())
-		return
+		return nil, err
+	}
+	topo, ok := resp.(*l8topo.L8Topology)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
+	}
+	return topo, nil
+}
+
+// ExportTopo renders a topology as a Graphviz dot graph, GraphML or JSON for
+// offline analysis. Nodes and links are written in key order so exports of
+// the same topology diff cleanly.
+func ExportTopo(name string, topo *l8topo.L8Topology, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case TopoJSON:
+		return protojson.MarshalOptions{Multiline: true}.Marshal(topo)
+	case TopoDot:
+		return exportDot(name, topo), nil
+	case TopoGraphML:
+		return exportGraphML(topo)
+	}
+	return nil, fmt.Errorf("unknown export format %q, expected dot, graphml or json", format)
+}
+
+func nodeKeys(topo *l8topo.L8Topology) []string {
+	keys := make([]string, 0, len(topo.Nodes))
+	for key := range topo.Nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func linkKeys(topo *l8topo.L8Topology) []string {
+	keys := make([]string, 0, len(topo.Links))
+	for key := range topo.Links {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func exportDot(name string, topo *l8topo.L8Topology) []byte {
+	buff := &bytes.Buffer{}
+	buff.WriteString("graph " + strconv.Quote(name) + " {\n")
+	for _, key := range nodeKeys(topo) {
+		node := topo.Nodes[key]
+		label := node.Name
+		if label == "" {
+			label = node.NodeId
+		}
+		fmt.Fprintf(buff, "  %s [label=%s];\n", strconv.Quote(node.NodeId), strconv.Quote(label))
+	}
+	for _, key := range linkKeys(topo) {
+		link := topo.Links[key]
+		fmt.Fprintf(buff, "  %s -- %s [id=%s, status=%s];\n", strconv.Quote(link.Aside), strconv.Quote(link.Zside),
+			strconv.Quote(link.LinkId), strconv.Quote(fmt.Sprint(link.Status)))
+	}
+	buff.WriteString("}\n")
+	return buff.Bytes()
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	Id   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	Id   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Id     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+func exportGraphML(topo *l8topo.L8Topology) ([]byte, error) {
+	doc := &graphML{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{Id: "name", For: "node", Name: "name", Type: "string"},
+			{Id: "status", For: "edge", Name: "status", Type: "string"},
+		},
+		Graph: graphMLGraph{EdgeDefault: "undirected"},
+	}
+	for _, key := range nodeKeys(topo) {
+		node := topo.Nodes[key]
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{Id: node.NodeId,
+			Data: []graphMLData{{Key: "name", Value: node.Name}}})
+	}
+	for _, key := range linkKeys(topo) {
+		link := topo.Links[key]
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{Id: link.LinkId, Source: link.Aside, Target: link.Zside,
+			Data: []graphMLData{{Key: "status", Value: fmt.Sprint(link.Status)}}})
 	}
-	jsn, err := protojson.Marshal(resp.(proto.Message))
+	out, err := xml.MarshalIndent(doc, "", "  ")
 	if err != nil {
-		fmt.Println(err)
-		return
+		return nil, err
 	}
-	fmt.Println(string(jsn))
-	os.WriteFile("/tmp/topo.json", jsn, 0777)
-}*/
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
@@ -0,0 +1,328 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/saichler/l8pollaris/go/pollaris/targets"
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+	common2 "github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8web/go/web/client"
+	"github.com/saichler/probler/go/prob/common"
+	"github.com/saichler/probler/go/prob/common/creates"
+	"sigs.k8s.io/yaml"
+)
+
+// InventoryProtocol is one protocol entry of an inventory row.
+type InventoryProtocol struct {
+	Name    string `json:"name"`
+	Port    int    `json:"port,omitempty"`
+	CredId  string `json:"credId,omitempty"`
+	Timeout int    `json:"timeout,omitempty"`
+}
+
+// InventoryRow is one device of an inventory file. L8PTarget has no location
+// fields, so Site and Rack only identify the row in the import report.
+type InventoryRow struct {
+	IP        string               `json:"ip"`
+	Type      string               `json:"type,omitempty"`
+	LinksId   string               `json:"linksId,omitempty"`
+	CredId    string               `json:"credId,omitempty"`
+	Site      string               `json:"site,omitempty"`
+	Rack      string               `json:"rack,omitempty"`
+	Protocols []*InventoryProtocol `json:"protocols,omitempty"`
+
+	line int
+	err  error
+}
+
+// Inventory is the YAML (or equivalent JSON) layout of an import file:
+//
+//	defaults:
+//	  credId: lab
+//	targets:
+//	  - ip: 10.1.1.1
+//	    type: network
+//	    site: dc1
+//	    rack: r12
+//	    protocols:
+//	      - name: snmp
+//	        port: 161
+//
+// The CSV layout has a header row with the columns
+// ip,type,linksId,credId,protocols,ports,timeout,site,rack where protocols and
+// ports are ';' separated and matched by position.
+type Inventory struct {
+	Defaults *InventoryRow   `json:"defaults,omitempty"`
+	Targets  []*InventoryRow `json:"targets"`
+}
+
+var inventoryTypes = map[string]struct {
+	targetType l8tpollaris.L8PTargetType
+	linksId    string
+}{
+	"network": {l8tpollaris.L8PTargetType_Network_Device, common.NetworkDevice_Links_ID},
+	"gpu":     {l8tpollaris.L8PTargetType_GPUS, common.GPU_Links_ID},
+}
+
+// LoadInventory reads a .yaml/.yml, .json or .csv inventory file. JSON uses
+// the same layout as YAML.
+func LoadInventory(filename string) (*Inventory, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml", ".json":
+		inv := &Inventory{}
+		if err = yaml.UnmarshalStrict(data, inv); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %s", filename, err.Error())
+		}
+		for i, row := range inv.Targets {
+			row.line = i + 1
+		}
+		return inv, nil
+	case ".csv":
+		return parseInventoryCSV(bytes.NewReader(data))
+	}
+	return nil, fmt.Errorf("unsupported inventory file %s, expected .yaml, .json or .csv", filename)
+}
+
+func parseInventoryCSV(r io.Reader) (*Inventory, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	// Rows may leave out trailing columns.
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("empty inventory file")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["ip"]; !ok {
+		return nil, errors.New("inventory csv has no ip column")
+	}
+	get := func(record []string, name string) string {
+		if i, ok := columns[strings.ToLower(name)]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	inv := &Inventory{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// The line in the file, counting the comments and blank lines the
+		// reader skipped.
+		line, _ := reader.FieldPos(0)
+		row := &InventoryRow{
+			IP:      get(record, "ip"),
+			Type:    get(record, "type"),
+			LinksId: get(record, "linksId"),
+			CredId:  get(record, "credId"),
+			Site:    get(record, "site"),
+			Rack:    get(record, "rack"),
+			line:    line,
+		}
+		timeout := 0
+		if value := get(record, "timeout"); value != "" {
+			if timeout, err = strconv.Atoi(value); err != nil {
+				row.err = fmt.Errorf("invalid timeout %q", value)
+			}
+		}
+		ports := strings.Split(get(record, "ports"), ";")
+		for j, name := range strings.Split(get(record, "protocols"), ";") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			p := &InventoryProtocol{Name: name, Timeout: timeout}
+			if j < len(ports) && strings.TrimSpace(ports[j]) != "" {
+				if p.Port, err = strconv.Atoi(strings.TrimSpace(ports[j])); err != nil && row.err == nil {
+					row.err = fmt.Errorf("invalid port %q for %s", strings.TrimSpace(ports[j]), name)
+				}
+			}
+			row.Protocols = append(row.Protocols, p)
+		}
+		inv.Targets = append(inv.Targets, row)
+	}
+	return inv, nil
+}
+
+// BuildInventoryTargets validates every row and builds its target. Rows that
+// fail validation are returned as errors keyed by their line in the file.
+func BuildInventoryTargets(inv *Inventory) ([]*l8tpollaris.L8PTarget, []string) {
+	defaults := inv.Defaults
+	if defaults == nil {
+		defaults = &InventoryRow{}
+	}
+	built := make([]*l8tpollaris.L8PTarget, 0, len(inv.Targets))
+	failures := make([]string, 0)
+	seen := make(map[string]int)
+	for _, row := range inv.Targets {
+		t, err := buildInventoryTarget(row, defaults)
+		if err == nil {
+			if first, ok := seen[t.TargetId]; ok {
+				err = fmt.Errorf("duplicate ip, first seen at row %d", first)
+			} else {
+				seen[t.TargetId] = row.line
+			}
+		}
+		if err != nil {
+			where := row.IP
+			if row.Site != "" || row.Rack != "" {
+				where += " " + row.Site + "/" + row.Rack
+			}
+			failures = append(failures, fmt.Sprintf("row %d (%s): %s", row.line, where, err.Error()))
+			continue
+		}
+		built = append(built, t)
+	}
+	return built, failures
+}
+
+func buildInventoryTarget(row, defaults *InventoryRow) (*l8tpollaris.L8PTarget, error) {
+	if row.err != nil {
+		return nil, row.err
+	}
+	ip, err := netip.ParseAddr(strings.TrimSpace(row.IP))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ip %q", row.IP)
+	}
+	typeName := strings.ToLower(firstOf(row.Type, defaults.Type, "network"))
+	invType, ok := inventoryTypes[typeName]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q, expected network or gpu", typeName)
+	}
+	linksId := firstOf(row.LinksId, defaults.LinksId, invType.linksId)
+	if targets.Links.Model(linksId) == "" {
+		return nil, fmt.Errorf("unknown links id %q", linksId)
+	}
+	credId := firstOf(row.CredId, defaults.CredId)
+	if credId == "" {
+		return nil, errors.New("no credId")
+	}
+
+	protocols := row.Protocols
+	if len(protocols) == 0 {
+		protocols = defaults.Protocols
+	}
+	specs := make([]*creates.ProtocolSpec, 0, len(protocols))
+	for _, p := range protocols {
+		protocol, err := creates.ParseProtocol(p.Name)
+		if err != nil {
+			return nil, err
+		}
+		if p.Port < 0 || p.Port > 65535 {
+			return nil, fmt.Errorf("invalid port for %s", p.Name)
+		}
+		if p.Timeout < 0 {
+			return nil, fmt.Errorf("invalid timeout for %s", p.Name)
+		}
+		specs = append(specs, &creates.ProtocolSpec{
+			Protocol: protocol,
+			Port:     int32(p.Port),
+			CredId:   p.CredId,
+			Timeout:  int32(p.Timeout),
+		})
+	}
+	return creates.CreateTarget(ip.String(), linksId, credId, invType.targetType, specs), nil
+}
+
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// ImportTargets loads an inventory file, validates it and posts the targets in
+// chunks. Every failed row or chunk is reported; the returned error is non nil
+// if anything failed.
+func ImportTargets(filename string, chunk int, dryRun bool, rc *client.RestClient, resources common2.IResources) error {
+	inv, err := LoadInventory(filename)
+	if err != nil {
+		return err
+	}
+	built, failures := BuildInventoryTargets(inv)
+	for _, failure := range failures {
+		fmt.Fprintln(os.Stderr, failure)
+	}
+	fmt.Fprintln(os.Stderr, "Validated", len(inv.Targets), "rows,", len(built), "valid,", len(failures), "invalid")
+	if dryRun || len(built) == 0 {
+		if len(failures) > 0 {
+			return fmt.Errorf("%d rows failed validation", len(failures))
+		}
+		return nil
+	}
+
+	failedPosts := postTargets(built, chunk, rc)
+	if len(failures) > 0 || failedPosts > 0 {
+		return fmt.Errorf("%d rows invalid, %d targets failed to post", len(failures), failedPosts)
+	}
+	return nil
+}
+
+// postTargets posts the targets in chunks, reporting progress and every target
+// of a failed chunk. It returns the number of targets that failed to post.
+func postTargets(list []*l8tpollaris.L8PTarget, chunk int, rc *client.RestClient) int {
+	if chunk <= 0 {
+		chunk = 500
+	}
+	posted := 0
+	failed := 0
+	for start := 0; start < len(list); start += chunk {
+		end := start + chunk
+		if end > len(list) {
+			end = len(list)
+		}
+		part := &l8tpollaris.L8PTargetList{List: list[start:end]}
+		_, err := rc.POST("91/"+targets.ServiceName, "L8PTargetList", "", "", part)
+		if err != nil {
+			for _, t := range part.List {
+				fmt.Fprintln(os.Stderr, t.TargetId, "post failed:", err.Error())
+			}
+			failed += len(part.List)
+			continue
+		}
+		posted += len(part.List)
+		fmt.Fprintf(os.Stderr, "Posted %d/%d targets\n", posted, len(list))
+	}
+	return failed
+}
@@ -0,0 +1,331 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/saichler/l8pollaris/go/pollaris/targets"
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+	"github.com/saichler/l8srlz/go/serialize/object"
+	common2 "github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8web/go/web/client"
+	"github.com/saichler/probler/go/prob/common/creates"
+)
+
+// TargetSelector picks the targets a lifecycle command applies to. Ids,
+// LinksId and Range are combined with AND; at least one must be set.
+// Range is a single IP, a CIDR (10.0.0.0/24) or a dash range (10.0.0.1-10.0.0.50)
+// and is matched against the TargetId.
+type TargetSelector struct {
+	Ids     []string
+	LinksId string
+	Range   string
+	Where   string
+}
+
+func (this *TargetSelector) Empty() bool {
+	return len(this.Ids) == 0 && this.LinksId == "" && this.Range == "" && this.Where == ""
+}
+
+// TargetUpdate holds the host protocol fields to change. Zero values are left
+// untouched.
+type TargetUpdate struct {
+	Protocol string
+	Addr     string
+	Port     int
+	CredId   string
+	Timeout  int
+}
+
+// ParseIPRange returns a matcher for a single IP, a CIDR or a dash range.
+func ParseIPRange(r string) (func(string) bool, error) {
+	start, end, err := ipRange(r)
+	if err != nil {
+		return nil, err
+	}
+	return func(s string) bool {
+		ip, err := netip.ParseAddr(s)
+		return err == nil && !ip.Less(start) && !end.Less(ip)
+	}, nil
+}
+
+// rangePatterns caps how many TargetId patterns IPRangeCondition puts in a
+// query before it falls back to coarser wildcards.
+const rangePatterns = 64
+
+// IPRangeCondition translates a range into an L8QL condition on TargetId so
+// the targets service does the narrowing. L8QL has no address comparison, so
+// the condition lists exact addresses, or wildcards on octet boundaries when
+// that would be too many, and may match a little more than the range; callers
+// still apply ParseIPRange to the result. It is empty for IPv6 ranges.
+func IPRangeCondition(r string) (string, error) {
+	start, end, err := ipRange(r)
+	if err != nil {
+		return "", err
+	}
+	if !start.Is4() || !end.Is4() {
+		return "", nil
+	}
+	from := ipv4ToUint(start)
+	to := ipv4ToUint(end)
+	for wild := 0; wild < 4; wild++ {
+		shift := uint(8 * wild)
+		count := (to >> shift) - (from >> shift)
+		if count >= rangePatterns {
+			continue
+		}
+		patterns := make([]string, 0, count+1)
+		for n := uint32(0); n <= count; n++ {
+			v := from>>shift + n
+			octets := make([]string, 0, 4)
+			for i := 3; i >= wild; i-- {
+				octets = append(octets, strconv.Itoa(int((v<<shift)>>(8*uint(i))&0xff)))
+			}
+			pattern := strings.Join(octets, ".")
+			if wild > 0 {
+				pattern += ".*"
+			}
+			patterns = append(patterns, "TargetId="+pattern)
+		}
+		return "(" + strings.Join(patterns, " or ") + ")", nil
+	}
+	return "", nil
+}
+
+// ipRange parses a single IP, a CIDR or a dash range into its first and last
+// address.
+func ipRange(r string) (netip.Addr, netip.Addr, error) {
+	r = strings.TrimSpace(r)
+	if strings.Contains(r, "/") {
+		prefix, err := netip.ParsePrefix(r)
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+		prefix = prefix.Masked()
+		last := prefix.Addr().AsSlice()
+		for bit := prefix.Bits(); bit < len(last)*8; bit++ {
+			last[bit/8] |= 0x80 >> uint(bit%8)
+		}
+		end, _ := netip.AddrFromSlice(last)
+		return prefix.Addr(), end, nil
+	}
+	from, to, isRange := strings.Cut(r, "-")
+	start, err := netip.ParseAddr(strings.TrimSpace(from))
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, err
+	}
+	end := start
+	if isRange {
+		if end, err = netip.ParseAddr(strings.TrimSpace(to)); err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+		if end.Less(start) {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid range %s, end is before start", r)
+		}
+	}
+	return start, end, nil
+}
+
+func ipv4ToUint(ip netip.Addr) uint32 {
+	b := ip.As4()
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// ListTargets returns the targets known to the targets service, optionally
+// narrowed by links id and an L8QL where expression.
+func ListTargets(linksId, where string, rc *client.RestClient, resources common2.IResources) (*l8tpollaris.L8PTargetList, error) {
+	conditions := make([]string, 0, 2)
+	if linksId != "" {
+		conditions = append(conditions, "LinksId="+linksId)
+	}
+	if where = strings.TrimSpace(where); where != "" {
+		conditions = append(conditions, "("+where+")")
+	}
+	query := "select * from L8PTarget"
+	if len(conditions) > 0 {
+		query += " where " + strings.Join(conditions, " and ")
+	}
+	return queryTargets(query, rc, resources)
+}
+
+func queryTargets(query string, rc *client.RestClient, resources common2.IResources) (*l8tpollaris.L8PTargetList, error) {
+	elems, e := object.NewQuery(query, resources)
+	if e != nil {
+		return nil, e
+	}
+	pq := elems.(*object.Elements).PQuery()
+	resp, err := rc.GET("0/"+targets.ServiceName, "L8PTargetList", "", "", pq)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := resp.(*l8tpollaris.L8PTargetList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
+	}
+	return list, nil
+}
+
+// SelectTargets resolves a selector to the matching targets.
+func SelectTargets(sel *TargetSelector, rc *client.RestClient, resources common2.IResources) ([]*l8tpollaris.L8PTarget, error) {
+	if sel.Empty() {
+		return nil, errors.New("no targets selected, give target ids, a links id, a range or a where expression")
+	}
+	var inRange func(string) bool
+	where := sel.Where
+	if sel.Range != "" {
+		var err error
+		if inRange, err = ParseIPRange(sel.Range); err != nil {
+			return nil, err
+		}
+		condition, err := IPRangeCondition(sel.Range)
+		if err != nil {
+			return nil, err
+		}
+		if condition != "" && strings.TrimSpace(where) != "" {
+			where = "(" + where + ") and " + condition
+		} else if condition != "" {
+			where = condition
+		}
+	}
+
+	var candidates []*l8tpollaris.L8PTarget
+	if len(sel.Ids) > 0 {
+		for _, id := range sel.Ids {
+			list, err := queryTargets("select * from L8PTarget where TargetId="+id, rc, resources)
+			if err != nil {
+				return nil, err
+			}
+			if len(list.List) == 0 {
+				return nil, fmt.Errorf("target %s not found", id)
+			}
+			candidates = append(candidates, list.List...)
+		}
+	} else {
+		list, err := ListTargets(sel.LinksId, where, rc, resources)
+		if err != nil {
+			return nil, err
+		}
+		candidates = list.List
+	}
+
+	selected := make([]*l8tpollaris.L8PTarget, 0, len(candidates))
+	for _, t := range candidates {
+		if sel.LinksId != "" && t.LinksId != sel.LinksId {
+			continue
+		}
+		if inRange != nil && !inRange(t.TargetId) {
+			continue
+		}
+		selected = append(selected, t)
+	}
+	return selected, nil
+}
+
+// SetTargetsState moves every selected target to the given state.
+func SetTargetsState(sel *TargetSelector, state l8tpollaris.L8PTargetState, rc *client.RestClient, resources common2.IResources) error {
+	return applyToTargets(sel, "set "+state.String(), rc, resources, func(t *l8tpollaris.L8PTarget) error {
+		t.State = state
+		return nil
+	})
+}
+
+// UpdateTargets changes host protocol settings on every selected target.
+func UpdateTargets(sel *TargetSelector, update *TargetUpdate, rc *client.RestClient, resources common2.IResources) error {
+	if update.Protocol == "" {
+		return errors.New("a protocol is required to update host protocol settings")
+	}
+	protocol, err := creates.ParseProtocol(update.Protocol)
+	if err != nil {
+		return err
+	}
+	return applyToTargets(sel, "update", rc, resources, func(t *l8tpollaris.L8PTarget) error {
+		if len(t.Hosts) == 0 {
+			return errors.New("target has no hosts")
+		}
+		for _, host := range t.Hosts {
+			if host.Configs == nil {
+				host.Configs = make(map[int32]*l8tpollaris.L8PHostProtocol)
+			}
+			cfg, ok := host.Configs[int32(protocol)]
+			if !ok {
+				cfg = &l8tpollaris.L8PHostProtocol{Protocol: protocol, Addr: host.HostId}
+				host.Configs[int32(protocol)] = cfg
+			}
+			if update.Addr != "" {
+				cfg.Addr = update.Addr
+			}
+			if update.Port > 0 {
+				cfg.Port = int32(update.Port)
+			}
+			if update.CredId != "" {
+				cfg.CredId = update.CredId
+			}
+			if update.Timeout > 0 {
+				cfg.Timeout = int32(update.Timeout)
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteTargets removes every selected target.
+func DeleteTargets(sel *TargetSelector, rc *client.RestClient, resources common2.IResources) error {
+	selected, err := SelectTargets(sel, rc, resources)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, t := range selected {
+		if err = DeleteTarget(t.TargetId, rc, resources); err != nil {
+			fmt.Fprintln(os.Stderr, t.TargetId, "delete failed:", err.Error())
+			failed++
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Deleted", len(selected)-failed, "of", len(selected), "targets")
+	if failed > 0 {
+		return fmt.Errorf("%d of %d targets failed to delete", failed, len(selected))
+	}
+	return nil
+}
+
+func applyToTargets(sel *TargetSelector, action string, rc *client.RestClient, resources common2.IResources,
+	change func(*l8tpollaris.L8PTarget) error) error {
+	selected, err := SelectTargets(sel, rc, resources)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, t := range selected {
+		if err = change(t); err == nil {
+			_, err = rc.PUT("0/"+targets.ServiceName, "L8PTarget", "", "", t)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, t.TargetId, action, "failed:", err.Error())
+			failed++
+		}
+	}
+	fmt.Fprintln(os.Stderr, action, "applied to", len(selected)-failed, "of", len(selected), "targets")
+	if failed > 0 {
+		return fmt.Errorf("%d of %d targets failed to %s", failed, len(selected), action)
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package creates
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+	"github.com/saichler/probler/go/prob/common"
+)
+
+// Fleet group types.
+const (
+	FleetSwitch  = "switch"
+	FleetRouter  = "router"
+	FleetGPU     = "gpu"
+	FleetCluster = "cluster"
+)
+
+// FleetGroup is a number of targets of one type allocated from an address pool.
+// Pool is a prefix; allocation starts at its address (e.g. 60.50.40.1/16 starts
+// at 60.50.40.1 and stays inside 60.50.0.0/16). Host addresses ending in .0 or
+// .255 are never allocated. Protocols maps a protocol name to the fraction of
+// the group that gets it; empty means the default mix of the group type, which
+// for switches and GPUs is the CreateDevice/CreateGPU protocol set.
+// Cluster groups take no pool, their targets are named after Names or
+// <fleet>-cluster-N and use the Kubernetes API instead of kubectl when the
+// drawn protocols include k8s.
+type FleetGroup struct {
+	Type      string             `json:"type"`
+	Count     int                `json:"count,omitempty"`
+	Names     []string           `json:"names,omitempty"`
+	Pool      string             `json:"pool,omitempty"`
+	LinksId   string             `json:"linksId,omitempty"`
+	Protocols map[string]float64 `json:"protocols,omitempty"`
+}
+
+// FleetSpec describes a simulator fleet. The same spec and seed always produce
+// the same targets, so a fleet can be torn down by regenerating it.
+type FleetSpec struct {
+	Name   string        `json:"name"`
+	Seed   int64         `json:"seed,omitempty"`
+	CredId string        `json:"credId,omitempty"`
+	Groups []*FleetGroup `json:"groups"`
+}
+
+// fleetTypeProtocols is the protocol mix of a group type that sets none.
+// Routers are mostly managed over SNMP with SSH enabled on only some of them,
+// unlike switches, which all have both.
+var fleetTypeProtocols = map[string]map[string]float64{
+	FleetRouter: {"snmp": 1, "ssh": 0.4},
+}
+
+var protocolNames = map[string]l8tpollaris.L8PProtocol{
+	"ssh":     l8tpollaris.L8PProtocol_L8PSSH,
+	"snmp":    l8tpollaris.L8PProtocol_L8PPSNMPV2,
+	"rest":    l8tpollaris.L8PProtocol_L8PRESTAPI,
+	"k8s":     l8tpollaris.L8PProtocol_L8PKubernetesAPI,
+	"kubectl": l8tpollaris.L8PProtocol_L8PKubectl,
+}
+
+// ParseProtocol accepts a short protocol name (ssh, snmp, rest, k8s, kubectl)
+// or the L8PProtocol enum name.
+func ParseProtocol(name string) (l8tpollaris.L8PProtocol, error) {
+	if p, ok := protocolNames[strings.ToLower(name)]; ok {
+		return p, nil
+	}
+	if v, ok := l8tpollaris.L8PProtocol_value[name]; ok {
+		return l8tpollaris.L8PProtocol(v), nil
+	}
+	return 0, fmt.Errorf("unknown protocol %q", name)
+}
+
+// Merge composes several specs into one, keeping group order. The first spec
+// with a seed or credId wins.
+func Merge(name string, specs ...*FleetSpec) *FleetSpec {
+	merged := &FleetSpec{Name: name}
+	for _, spec := range specs {
+		if merged.Seed == 0 {
+			merged.Seed = spec.Seed
+		}
+		if merged.CredId == "" {
+			merged.CredId = spec.CredId
+		}
+		merged.Groups = append(merged.Groups, spec.Groups...)
+	}
+	return merged
+}
+
+// ipAllocator hands out unique host addresses across every pool of a fleet.
+type ipAllocator struct {
+	used map[netip.Addr]bool
+}
+
+func (this *ipAllocator) allocate(pool string, count int) ([]string, error) {
+	prefix, err := netip.ParsePrefix(pool)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pool %q: %s", pool, err.Error())
+	}
+	if !prefix.Addr().Is4() {
+		return nil, fmt.Errorf("pool %q is not IPv4", pool)
+	}
+	ips := make([]string, 0, count)
+	for ip := prefix.Addr(); prefix.Contains(ip) && len(ips) < count; ip = ip.Next() {
+		last := ip.As4()[3]
+		if last == 0 || last == 255 || this.used[ip] {
+			continue
+		}
+		this.used[ip] = true
+		ips = append(ips, ip.String())
+	}
+	if len(ips) < count {
+		return nil, fmt.Errorf("pool %s has room for %d more addresses, %d requested", pool, len(ips), count)
+	}
+	return ips, nil
+}
+
+// CreateFleet generates every target of a fleet spec.
+func CreateFleet(spec *FleetSpec) ([]*l8tpollaris.L8PTarget, error) {
+	if spec == nil || len(spec.Groups) == 0 {
+		return nil, errors.New("fleet spec has no groups")
+	}
+	credId := spec.CredId
+	if credId == "" {
+		credId = "sim"
+	}
+	rnd := rand.New(rand.NewSource(spec.Seed))
+	alloc := &ipAllocator{used: make(map[netip.Addr]bool)}
+	fleet := make([]*l8tpollaris.L8PTarget, 0)
+	clusters := 0
+
+	for i, group := range spec.Groups {
+		count := group.Count
+		if count == 0 {
+			count = len(group.Names)
+		}
+		if count <= 0 {
+			return nil, fmt.Errorf("group %d: count must be positive", i+1)
+		}
+		protocols := group.Protocols
+		if len(protocols) == 0 {
+			protocols = fleetTypeProtocols[group.Type]
+		}
+		mix, err := protocolMix(protocols)
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %s", i+1, err.Error())
+		}
+
+		if group.Type == FleetCluster {
+			for j := 0; j < count; j++ {
+				clusters++
+				name := spec.Name + "-cluster-" + strconv.Itoa(clusters)
+				if j < len(group.Names) {
+					name = group.Names[j]
+				}
+				fleet = append(fleet, createFleetCluster(name, pickProtocols(mix, rnd)))
+			}
+			continue
+		}
+
+		invType := l8tpollaris.L8PTargetType_Network_Device
+		linksId := common.NetworkDevice_Links_ID
+		switch group.Type {
+		case FleetSwitch, FleetRouter:
+		case FleetGPU:
+			invType = l8tpollaris.L8PTargetType_GPUS
+			linksId = common.GPU_Links_ID
+		default:
+			return nil, fmt.Errorf("group %d: unknown type %q", i+1, group.Type)
+		}
+		if group.LinksId != "" {
+			linksId = group.LinksId
+		}
+		if group.Pool == "" {
+			return nil, fmt.Errorf("group %d: no pool", i+1)
+		}
+		ips, err := alloc.allocate(group.Pool, count)
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %s", i+1, err.Error())
+		}
+		for _, ip := range ips {
+			fleet = append(fleet, CreateTarget(ip, linksId, credId, invType, pickProtocols(mix, rnd)))
+		}
+	}
+	return fleet, nil
+}
+
+func createFleetCluster(name string, protocols []*ProtocolSpec) *l8tpollaris.L8PTarget {
+	for _, p := range protocols {
+		if p.Protocol == l8tpollaris.L8PProtocol_L8PKubernetesAPI {
+			return CreateCluster2(name)
+		}
+	}
+	return CreateCluster(name)
+}
+
+type protocolShare struct {
+	protocol l8tpollaris.L8PProtocol
+	share    float64
+}
+
+// protocolMix orders the shares by protocol so the random draws, and with them
+// the generated fleet, do not depend on map iteration order.
+func protocolMix(protocols map[string]float64) ([]*protocolShare, error) {
+	mix := make([]*protocolShare, 0, len(protocols))
+	for name, share := range protocols {
+		p, err := ParseProtocol(name)
+		if err != nil {
+			return nil, err
+		}
+		if share < 0 || share > 1 {
+			return nil, fmt.Errorf("share of %s must be between 0 and 1", name)
+		}
+		mix = append(mix, &protocolShare{protocol: p, share: share})
+	}
+	sort.Slice(mix, func(i, j int) bool {
+		return mix[i].protocol < mix[j].protocol
+	})
+	return mix, nil
+}
+
+// pickProtocols draws the protocol set of one device. A device always gets at
+// least the most common protocol of the mix.
+func pickProtocols(mix []*protocolShare, rnd *rand.Rand) []*ProtocolSpec {
+	if len(mix) == 0 {
+		return nil
+	}
+	specs := make([]*ProtocolSpec, 0, len(mix))
+	top := mix[0]
+	for _, ps := range mix {
+		if ps.share > top.share {
+			top = ps
+		}
+		if rnd.Float64() < ps.share {
+			specs = append(specs, &ProtocolSpec{Protocol: ps.protocol})
+		}
+	}
+	if len(specs) == 0 {
+		specs = append(specs, &ProtocolSpec{Protocol: top.protocol})
+	}
+	return specs
+}
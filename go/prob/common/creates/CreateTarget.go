@@ -0,0 +1,71 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package creates
+
+import (
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtocolSpec is one host protocol of a target. Port and Timeout fall back to
+// the protocol defaults used by CreateDevice/CreateGPU when zero.
+type ProtocolSpec struct {
+	Protocol l8tpollaris.L8PProtocol
+	Port     int32
+	CredId   string
+	Timeout  int32
+}
+
+// CreateTarget builds a device or GPU target with an explicit protocol set.
+// With no protocols it is equivalent to CreateDevice/CreateGPU.
+func CreateTarget(ip, linksId, crId string, inventoryType l8tpollaris.L8PTargetType, protocols []*ProtocolSpec) *l8tpollaris.L8PTarget {
+	var device *l8tpollaris.L8PTarget
+	if inventoryType == l8tpollaris.L8PTargetType_GPUS {
+		device = CreateGPU(ip, linksId, crId)
+	} else {
+		device = CreateDevice(ip, linksId, crId)
+	}
+	if len(protocols) == 0 {
+		return device
+	}
+
+	host := device.Hosts[ip]
+	defaults := host.Configs
+	host.Configs = make(map[int32]*l8tpollaris.L8PHostProtocol)
+	for _, spec := range protocols {
+		config := &l8tpollaris.L8PHostProtocol{}
+		if def, ok := defaults[int32(spec.Protocol)]; ok {
+			config = proto.Clone(def).(*l8tpollaris.L8PHostProtocol)
+		}
+		config.Protocol = spec.Protocol
+		config.Addr = ip
+		config.CredId = crId
+		if spec.CredId != "" {
+			config.CredId = spec.CredId
+		}
+		if spec.Port > 0 {
+			config.Port = spec.Port
+		}
+		if spec.Timeout > 0 {
+			config.Timeout = spec.Timeout
+		}
+		if config.Timeout == 0 {
+			config.Timeout = 60
+		}
+		host.Configs[int32(config.Protocol)] = config
+	}
+	return device
+}
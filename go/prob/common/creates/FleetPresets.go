@@ -0,0 +1,89 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package creates
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The simulator presets, expressed as fleet specs. Every preset has its own
+// pool so any of them can be brought up next to any other.
+var fleetPresets = map[string]*FleetSpec{
+	"gpus":   gpuPreset("gpus", 3, "20.20.30.1/24"),
+	"1Kgpus": gpuPreset("1Kgpus", 1000, "20.21.10.1/16"),
+	"5Kgpus": gpuPreset("5Kgpus", 5000, "20.20.10.1/16"),
+	"cluster": {Name: "cluster",
+		Groups: []*FleetGroup{{Type: FleetCluster, Names: []string{"lab"}}}},
+	"cluster2": {Name: "cluster2",
+		Groups: []*FleetGroup{{Type: FleetCluster, Names: []string{"lab2"}, Protocols: map[string]float64{"k8s": 1}}}},
+	"base": devicePreset("base", 19, "10.20.30.1/24"),
+	"D1":   devicePreset("D1", 1000, "30.20.10.1/16"),
+	"D2":   devicePreset("D2", 1000, "40.20.10.1/16"),
+	"D3":   devicePreset("D3", 1000, "50.20.10.1/16"),
+	"500":  devicePreset("500", 500, "60.10.0.1/16"),
+	"1K":   devicePreset("1K", 1000, "60.20.0.1/16"),
+	"3K":   devicePreset("3K", 3000, "60.30.0.1/16"),
+	"5K":   devicePreset("5K", 5000, "60.40.0.1/16"),
+	"10K":  devicePreset("10K", 10000, "60.50.40.1/16"),
+	"20K":  devicePreset("20K", 20000, "60.70.0.1/16"),
+	"25K":  devicePreset("25K", 25000, "60.80.0.1/16"),
+	"30K":  devicePreset("30K", 30000, "60.90.0.1/16"),
+}
+
+// allPreset lists the presets the "all" preset composes, in allocation order.
+var allPreset = []string{"gpus", "5Kgpus", "1Kgpus", "cluster", "cluster2", "base", "D1", "D2", "D3"}
+
+func devicePreset(name string, count int, pool string) *FleetSpec {
+	return &FleetSpec{Name: name, CredId: "sim",
+		Groups: []*FleetGroup{{Type: FleetSwitch, Count: count, Pool: pool}}}
+}
+
+func gpuPreset(name string, count int, pool string) *FleetSpec {
+	return &FleetSpec{Name: name, CredId: "sim",
+		Groups: []*FleetGroup{{Type: FleetGPU, Count: count, Pool: pool}}}
+}
+
+// FleetPreset returns the spec of a named preset or of a comma separated list
+// of presets composed together. "all" expands to every layered preset.
+func FleetPreset(names string) (*FleetSpec, error) {
+	specs := make([]*FleetSpec, 0)
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "all" {
+			for _, n := range allPreset {
+				specs = append(specs, fleetPresets[n])
+			}
+			continue
+		}
+		spec, ok := fleetPresets[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown fleet preset %q, expected one of %s", name, strings.Join(FleetPresetNames(), ", "))
+		}
+		specs = append(specs, spec)
+	}
+	return Merge(names, specs...), nil
+}
+
+func FleetPresetNames() []string {
+	names := make([]string, 0, len(fleetPresets)+1)
+	for name := range fleetPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append(names, "all")
+}
@@ -41,12 +41,95 @@ const (
 	DB_ALARMS_NAME  = "probleralarms"
 )*/
 
+// effectiveBoot is the bootstrap config CreateResources applied.
+var effectiveBoot = &BootConfig{}
+
+// Boot returns the bootstrap config CreateResources applied.
+func Boot() *BootConfig {
+	return effectiveBoot
+}
+
 func init() {
-	targets.Links = &Links{}
+	targets.Links = &tracingLinks{Links: &Links{}}
 }
 
-func CreateResources(alias string) ifs.IResources {
+// CreateResources builds the resources of a service process. defaults are the
+// binary's own boot defaults, nil for the common ones. args are the process
+// arguments without the program name, parsed for the BootConfig flags.
+func CreateResources(alias string, defaults BootDefaults, args ...string) ifs.IResources {
+	res := baseResources(alias)
+	log := res.Logger()
 
+	sc := res.SysConfig()
+	current := NewBootConfig(bootGroups...)
+	current.VnetHost, current.VnetPort = sc.RemoteVnet, sc.VnetPort
+	current.TxQueueSize, current.RxQueueSize = sc.TxQueueSize, sc.RxQueueSize
+	if sc.DataStoreConfig != nil {
+		current.DataStore = sc.DataStoreConfig.Name
+	}
+	if sc.TimeSeriesStoreConfig != nil {
+		current.TimeSeriesStore = sc.TimeSeriesStoreConfig.Name
+	}
+	boot, err := LoadBootConfig(bootDefaults(current, defaults, res), args)
+	if err == nil {
+		err = boot.Apply(res)
+	}
+	if err != nil {
+		log.Error("Invalid bootstrap config: ", err.Error())
+		os.Exit(1)
+	}
+	log.Info("Effective config:", boot.String())
+	effectiveBoot = boot
+	startProbes(int(boot.ProbePort), res)
+
+	finishResources(res, alias)
+
+	if filename := os.Getenv(LinksFileEnv); filename != "" {
+		if err = LoadLinksFile(filename); err != nil {
+			log.Error(err.Error())
+			os.Exit(1)
+		}
+	}
+
+	// Misrouted CJobs vanish without an error, so refuse to start on bad tables.
+	if err = ValidateLinks(res); err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	return res
+}
+
+// NewClientResources builds the resources of a command line client, like
+// prctl, which has its own flags and output. It logs errors only, takes no
+// boot config and starts no probe endpoints; the links file, if set,
+// is loaded so the client routes like the services.
+func NewClientResources(alias string) (ifs.IResources, error) {
+	res := baseResources(alias)
+	res.Logger().SetLogLevel(ifs.Error_Level)
+	finishResources(res, alias)
+	if filename := os.Getenv(LinksFileEnv); filename != "" {
+		if err := LoadLinksFile(filename); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// NewResources builds the resources of one more vnic in a process that
+// already called CreateResources, e.g. one per service group in the all in
+// one binary. It reuses the effective bootstrap config.
+func NewResources(alias string) ifs.IResources {
+	res := baseResources(alias)
+	if err := effectiveBoot.Apply(res); err != nil {
+		res.Logger().Error("Invalid bootstrap config: ", err.Error())
+		os.Exit(1)
+	}
+	finishResources(res, alias)
+	return res
+}
+
+func baseResources(alias string) ifs.IResources {
 	log := logger.NewLoggerImpl(&logger.FmtLogMethod{})
 	log.SetLogLevel(ifs.Info_Level)
 	res := resources.NewResources(log)
@@ -55,7 +138,7 @@ func CreateResources(alias string) ifs.IResources {
 
 	sec, err := sec.LoadSecurityProvider(res)
 	if err != nil {
-		fmt.Println("Failed to load security provider", err)
+		fmt.Fprintln(os.Stderr, "Failed to load security provider", err)
 	} else {
 		res.Set(sec)
 	}
@@ -63,20 +146,27 @@ func CreateResources(alias string) ifs.IResources {
 	if res.SysConfig().LogConfig != nil && res.SysConfig().LogConfig.LogDirectory != "" {
 		logger.SetLogToFile(res.SysConfig().LogConfig.LogDirectory, alias)
 	}
+	return res
+}
 
+func finishResources(res ifs.IResources, alias string) {
 	res.SysConfig().LocalAlias = alias + "-" + strconv.Itoa(int(res.SysConfig().VnetPort))
-	res.SysConfig().KeepAliveIntervalSeconds = 30
-
 	res.Set(introspecting.NewIntrospect(res.Registry()))
+	RegisterLinkModels(res)
 	res.Set(manager.NewServices(res))
-
-	return res
+	registerComponent(alias, res)
 }
 
+// WaitForSignal marks the process as activated for /readyz, blocks until
+// SIGINT or SIGTERM and then runs the shutdown hooks, see OnShutdown. A second
+// signal exits immediately.
 func WaitForSignal(resources ifs.IResources) {
 	resources.Logger().Info("Waiting for os signal...")
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	probes.activated.Store(true)
 	sig := <-sigs
 	resources.Logger().Info("End signal received! ", sig)
+	go exitOnSecondSignal(sigs, resources)
+	Shutdown(resources)
 }
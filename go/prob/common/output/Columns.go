@@ -0,0 +1,194 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/saichler/l8types/go/ifs"
+	"google.golang.org/protobuf/proto"
+)
+
+// tableColumns is how many columns the narrow table shows when no explicit
+// column list is given. The wide format shows every scalar column.
+const tableColumns = 6
+
+// rowsOf returns the rows to render for a response. List messages (no scalar
+// fields of their own, e.g. NetworkDeviceList or L8Top) yield the elements of
+// their first repeated or map-of-message field; anything else is a single row.
+func rowsOf(msg proto.Message) []reflect.Value {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	s := v.Elem()
+	if s.Kind() != reflect.Struct {
+		return []reflect.Value{v}
+	}
+	var container reflect.Value
+	for i := 0; i < s.NumField(); i++ {
+		field := s.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if isScalar(field.Type) {
+			return []reflect.Value{v}
+		}
+		if !container.IsValid() && isMessageContainer(field.Type) {
+			container = s.Field(i)
+		}
+	}
+	if !container.IsValid() {
+		return []reflect.Value{v}
+	}
+	rows := make([]reflect.Value, 0, container.Len())
+	if container.Kind() == reflect.Slice {
+		for i := 0; i < container.Len(); i++ {
+			if !container.Index(i).IsNil() {
+				rows = append(rows, container.Index(i))
+			}
+		}
+		return rows
+	}
+	keys := container.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	for _, key := range keys {
+		if elem := container.MapIndex(key); !elem.IsNil() {
+			rows = append(rows, elem)
+		}
+	}
+	return rows
+}
+
+// defaultColumns selects the scalar attributes of the row type, in field
+// order. The introspector decides which attributes are scalar so the columns
+// match what the rest of the system sees for the type.
+func defaultColumns(row reflect.Value, resources ifs.IResources) []string {
+	t := row.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var attributes map[string]bool
+	if resources != nil && resources.Introspector() != nil {
+		if msg, ok := row.Interface().(proto.Message); ok {
+			if node, err := resources.Introspector().Inspect(msg); err == nil && node != nil {
+				attributes = make(map[string]bool)
+				for name, attr := range node.Attributes {
+					attributes[name] = !attr.IsStruct && !attr.IsMap && !attr.IsSlice
+				}
+			}
+		}
+	}
+	columns := make([]string, 0)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if attributes != nil {
+			if scalar, ok := attributes[field.Name]; ok {
+				if scalar {
+					columns = append(columns, field.Name)
+				}
+				continue
+			}
+		}
+		if isScalar(field.Type) {
+			columns = append(columns, field.Name)
+		}
+	}
+	return columns
+}
+
+// valueOf resolves a dotted Go field path (e.g. "Equipmentinfo.SysName")
+// against a row and formats it for display.
+func valueOf(row reflect.Value, path string) string {
+	v := row
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return ""
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return ""
+		}
+		v = v.FieldByName(name)
+		if !v.IsValid() {
+			return ""
+		}
+	}
+	return format(v)
+}
+
+func format(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return ""
+	}
+	if v.Kind() == reflect.Ptr && isScalar(v.Type().Elem()) {
+		v = v.Elem()
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok && v.Kind() != reflect.Ptr {
+		return s.String()
+	}
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("%d bytes", v.Len())
+		}
+		if isScalar(v.Type().Elem()) {
+			items := make([]string, v.Len())
+			for i := 0; i < v.Len(); i++ {
+				items[i] = format(v.Index(i))
+			}
+			return strings.Join(items, ",")
+		}
+		return fmt.Sprintf("[%d]", v.Len())
+	case reflect.Map:
+		return fmt.Sprintf("{%d}", v.Len())
+	case reflect.Ptr, reflect.Struct:
+		return "{...}"
+	case reflect.Float32, reflect.Float64:
+		return fmt.Sprintf("%.2f", v.Float())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return t.Elem().Kind() != reflect.Struct && isScalar(t.Elem())
+	case reflect.Struct, reflect.Map, reflect.Interface:
+		return false
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8
+	}
+	return true
+}
+
+func isMessageContainer(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Map {
+		return false
+	}
+	elem := t.Elem()
+	return elem.Kind() == reflect.Ptr && elem.Elem().Kind() == reflect.Struct
+}
@@ -0,0 +1,206 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package output renders any proto response (a single object or a list such as
+// NetworkDeviceList, K8SPodList or L8HealthList) as a table, JSON, YAML or CSV.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/saichler/l8types/go/ifs"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+)
+
+type Format string
+
+const (
+	Table Format = "table"
+	Wide  Format = "wide"
+	JSON  Format = "json"
+	YAML  Format = "yaml"
+	CSV   Format = "csv"
+)
+
+// Formats lists the accepted -o values, for help text.
+const Formats = "table|wide|json|yaml|csv"
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case Table, Wide, JSON, YAML, CSV:
+		return f, nil
+	case "":
+		return Table, nil
+	}
+	return "", fmt.Errorf("unknown output format %q, expected %s", s, Formats)
+}
+
+type Printer struct {
+	format    Format
+	columns   []string
+	resources ifs.IResources
+	out       io.Writer
+}
+
+// NewPrinter creates a printer. columns optionally overrides the introspected
+// column set for table, wide and csv output; entries may be dotted paths.
+func NewPrinter(format Format, columns []string, resources ifs.IResources, out io.Writer) *Printer {
+	return &Printer{format: format, columns: columns, resources: resources, out: out}
+}
+
+func (this *Printer) Format() Format {
+	return this.format
+}
+
+func (this *Printer) Print(msg proto.Message) error {
+	if msg == nil {
+		return nil
+	}
+	switch this.format {
+	case JSON:
+		return this.printJSON(msg)
+	case YAML:
+		return this.printYAML(msg)
+	case CSV:
+		return this.printCSV(msg)
+	case Wide:
+		return this.printTable(msg, true)
+	}
+	return this.printTable(msg, false)
+}
+
+// PrintRows prints a report that is not a proto message. Table, wide and csv
+// output use the given columns and rows; json and yaml marshal value instead.
+func (this *Printer) PrintRows(columns []string, rows [][]string, value interface{}) error {
+	switch this.format {
+	case JSON:
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(this.out, string(data))
+		return err
+	case YAML:
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, err = this.out.Write(data)
+		return err
+	case CSV:
+		w := csv.NewWriter(this.out)
+		if err := w.Write(columns); err != nil {
+			return err
+		}
+		if err := w.WriteAll(rows); err != nil {
+			return err
+		}
+		return w.Error()
+	}
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(this.out, "No resources found")
+		return err
+	}
+	return this.writeTable(columns, rows)
+}
+
+func (this *Printer) printJSON(msg proto.Message) error {
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(this.out, string(data))
+	return err
+}
+
+func (this *Printer) printYAML(msg proto.Message) error {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	data, err = yaml.JSONToYAML(data)
+	if err != nil {
+		return err
+	}
+	_, err = this.out.Write(data)
+	return err
+}
+
+func (this *Printer) columnsFor(msg proto.Message, wide bool) ([]string, [][]string) {
+	rows := rowsOf(msg)
+	if len(rows) == 0 {
+		return this.columns, nil
+	}
+	columns := this.columns
+	if len(columns) == 0 {
+		columns = defaultColumns(rows[0], this.resources)
+		if !wide && len(columns) > tableColumns {
+			columns = columns[:tableColumns]
+		}
+	}
+	values := make([][]string, len(rows))
+	for i, row := range rows {
+		values[i] = make([]string, len(columns))
+		for j, column := range columns {
+			values[i][j] = valueOf(row, column)
+		}
+	}
+	return columns, values
+}
+
+func (this *Printer) printTable(msg proto.Message, wide bool) error {
+	columns, rows := this.columnsFor(msg, wide)
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(this.out, "No resources found")
+		return err
+	}
+	// Nothing tabular to show (e.g. only nested objects), fall back to YAML.
+	if len(columns) == 0 {
+		return this.printYAML(msg)
+	}
+	return this.writeTable(columns, rows)
+}
+
+func (this *Printer) writeTable(columns []string, rows [][]string) error {
+	w := tabwriter.NewWriter(this.out, 0, 0, 2, ' ', 0)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = strings.ToUpper(column)
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+func (this *Printer) printCSV(msg proto.Message) error {
+	columns, rows := this.columnsFor(msg, true)
+	w := csv.NewWriter(this.out)
+	if err := w.Write(columns); err != nil {
+		return err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
@@ -16,17 +16,28 @@
 package main
 
 import (
+	"context"
 	"github.com/saichler/l8bus/go/overlay/vnet"
 	"github.com/saichler/l8logfusion/go/agent/logserver"
+	"github.com/saichler/l8types/go/ifs"
 	"github.com/saichler/probler/go/prob/common"
+	"os"
 )
 
 func main() {
 	logsDbDirectory := "/data/logsdb/probler"
-	resources := common.CreateResources("log-vnet")
-	resources.SysConfig().VnetPort = resources.SysConfig().LogConfig.VnetPort
+	// The logs vnet listens on the log port of the system config.
+	resources := common.CreateResources("log-vnet", func(boot *common.BootConfig, res ifs.IResources) {
+		if lc := res.SysConfig().LogConfig; lc != nil {
+			boot.VnetPort = lc.VnetPort
+		}
+	}, os.Args[1:]...)
 	net := vnet.NewVNet(resources, true)
 	net.Start()
+	common.OnShutdown(common.ShutdownNetwork, "vnet", func(ctx context.Context) error {
+		net.Shutdown()
+		return nil
+	})
 	logserver.ActivateLogService(logsDbDirectory, net.VnetVnic())
 	resources.Logger().Info("logs vnet started!")
 	common.WaitForSignal(resources)
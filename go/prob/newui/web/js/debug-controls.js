@@ -0,0 +1,133 @@
+// Probler-specific System tab: send debug controls to the Debug service on
+// the bus (/0/Debug). The service forwards a control to the process of the
+// alias, or to every process when the alias is empty.
+(function() {
+    'use strict';
+
+    var ACTIONS = {
+        level: 'DEBUG_ACTION_LOG_LEVEL',
+        trace: 'DEBUG_ACTION_TRACE',
+        untrace: 'DEBUG_ACTION_UNTRACE',
+        goroutines: 'DEBUG_ACTION_DUMP_GOROUTINES',
+        heap: 'DEBUG_ACTION_DUMP_HEAP'
+    };
+
+    function escapeHtml(str) {
+        if (!str) return '';
+        return String(str).replace(/&/g, '&amp;').replace(/</g, '&lt;').replace(/>/g, '&gt;').replace(/"/g, '&quot;');
+    }
+
+    function debugEndpoint() {
+        var prefix = typeof Layer8DConfig !== 'undefined' ? Layer8DConfig.getApiPrefix() : '/probler';
+        return prefix + '/0/Debug';
+    }
+
+    function formHtml() {
+        return '<div class="debug-controls" style="padding:20px;max-width:720px;">' +
+            '<div class="subsection-description">Change the log level, trace the routes of a links id or dump a running process. ' +
+            'The alias is the one the Health tab shows; leave it empty for every process.</div>' +
+            '<div style="display:grid;grid-template-columns:140px 1fr;gap:10px;align-items:center;margin-top:16px;">' +
+            '<label for="debug-alias">Alias</label><input id="debug-alias" type="text" placeholder="every process">' +
+            '<label for="debug-action">Control</label><select id="debug-action">' +
+            '<option value="level">Log level</option>' +
+            '<option value="trace">Trace links id</option>' +
+            '<option value="untrace">Stop tracing links id</option>' +
+            '<option value="goroutines">Goroutine dump</option>' +
+            '<option value="heap">Heap profile</option>' +
+            '</select>' +
+            '<label for="debug-level">Level</label><select id="debug-level">' +
+            '<option>trace</option><option>debug</option><option selected>info</option><option>warning</option><option>error</option>' +
+            '</select>' +
+            '<label for="debug-component">Component</label><input id="debug-component" type="text" placeholder="every component, e.g. parser">' +
+            '<label for="debug-linksid">Links id</label><input id="debug-linksid" type="text" placeholder="list the traced links ids">' +
+            '</div>' +
+            '<div style="margin-top:16px;"><button type="button" class="btn" id="debug-send">Send</button></div>' +
+            '<div id="debug-result" style="margin-top:16px;"></div>' +
+            '</div>';
+    }
+
+    function value(id) {
+        var el = document.getElementById(id);
+        return el ? el.value.trim() : '';
+    }
+
+    function showResult(html) {
+        var el = document.getElementById('debug-result');
+        if (el) el.innerHTML = html;
+    }
+
+    function download(alias, kind, base64) {
+        var raw = atob(base64 || '');
+        var bytes = new Uint8Array(raw.length);
+        for (var i = 0; i < raw.length; i++) bytes[i] = raw.charCodeAt(i);
+        var link = document.createElement('a');
+        link.href = URL.createObjectURL(new Blob([bytes], { type: 'application/octet-stream' }));
+        link.download = alias + (kind === 'heap' ? '-heap.pprof' : '-goroutines.txt');
+        document.body.appendChild(link);
+        link.click();
+        document.body.removeChild(link);
+        URL.revokeObjectURL(link.href);
+    }
+
+    async function send() {
+        var kind = value('debug-action');
+        var control = { alias: value('debug-alias'), action: ACTIONS[kind] };
+        if (kind === 'level') {
+            control.level = value('debug-level');
+            control.component = value('debug-component');
+        } else if (kind === 'trace' || kind === 'untrace') {
+            control.linksId = value('debug-linksid');
+        } else if (!control.alias) {
+            showResult('<p style="color:#e53e3e;">A dump is of one process, enter its alias.</p>');
+            return;
+        }
+
+        var response = await makeAuthenticatedRequest(debugEndpoint(), {
+            method: 'POST',
+            body: JSON.stringify(control)
+        });
+        if (!response) return;
+        if (!response.ok) {
+            var text = '';
+            try { text = await response.text(); } catch (e) {}
+            showResult('<p style="color:#e53e3e;">' + escapeHtml(text || ('HTTP ' + response.status)) + '</p>');
+            return;
+        }
+        var replies = ((await response.json()) || {}).list || [];
+        var rows = replies.map(function(reply) {
+            if (reply.error) {
+                return '<li><b>' + escapeHtml(reply.alias) + '</b>: <span style="color:#e53e3e;">' + escapeHtml(reply.error) + '</span></li>';
+            }
+            if (kind === 'goroutines' || kind === 'heap') {
+                download(reply.alias, kind, reply.dump);
+                return '<li><b>' + escapeHtml(reply.alias) + '</b>: dump downloaded</li>';
+            }
+            return '<li><b>' + escapeHtml(reply.alias) + '</b>: ' + escapeHtml(reply.message) + '</li>';
+        });
+        showResult(rows.length ? '<ul>' + rows.join('') + '</ul>' : '<p>No process answered.</p>');
+    }
+
+    // The l8ui tabs know the shared modules only, so the Debug tab switches
+    // itself in.
+    function showTab(tab) {
+        document.querySelectorAll('.l8-module-tab').forEach(function(t) {
+            t.classList.toggle('active', t === tab);
+        });
+        document.querySelectorAll('.l8-module-content').forEach(function(c) {
+            c.classList.toggle('active', c.getAttribute('data-module') === 'debug');
+        });
+    }
+
+    window.initializeDebugControls = function() {
+        var container = document.getElementById('debug-controls-container');
+        var tab = document.querySelector('.l8-module-tab[data-module="debug"]');
+        if (!container || !tab) return;
+        container.innerHTML = formHtml();
+        tab.addEventListener('click', function() { showTab(tab); });
+        document.getElementById('debug-send').addEventListener('click', function() {
+            send().catch(function(error) {
+                showResult('<p style="color:#e53e3e;">' + escapeHtml(error.message) + '</p>');
+            });
+        });
+    };
+})();
@@ -97,6 +97,9 @@ function loadSection(sectionName) {
                         if (typeof initializeL8Sys === 'function') {
                             initializeL8Sys();
                         }
+                        if (typeof initializeDebugControls === 'function') {
+                            initializeDebugControls();
+                        }
                         if (typeof initializeParallax === 'function') {
                             initializeParallax();
                         }
@@ -16,49 +16,27 @@
 package main
 
 import (
-	"fmt"
 	"github.com/saichler/l8bus/go/overlay/vnic"
-	"github.com/saichler/l8events/go/services"
-	"github.com/saichler/l8pollaris/go/pollaris/targets"
 	"github.com/saichler/l8types/go/ifs"
 	"github.com/saichler/probler/go/prob/common"
+	"github.com/saichler/probler/go/prob/common/activate"
 	"os"
-	"os/exec"
-	"time"
 )
 
 func main() {
-	res := common.CreateResources("orm")
+	common.RegisterBootSettings(common.BootDb)
+	res := common.CreateResources("orm", nil, os.Args[1:]...)
 	ifs.SetNetworkMode(ifs.NETWORK_K8s)
 	nic := vnic.NewVirtualNetworkInterface(res, nil)
 	nic.Start()
+	activate.Vnic(nic)
 	nic.WaitForConnection()
 
-	//Start postgres
-	if len(os.Args) <= 1 {
-		startDb(nic)
+	//Start postgres, or connect to the one of --db-host
+	if err := activate.Orm(nic); err != nil {
+		res.Logger().Error(err.Error())
+		os.Exit(1)
 	}
 
-	//Activate targets
-	targets.Activate(nic.Resources().SysConfig().DataStoreConfig.Type, nic.Resources().SysConfig().DataStoreConfig.Name, nic)
-
-	//Activate Events
-	services.ActivateEvents(nic.Resources().SysConfig().DataStoreConfig.Type, nic.Resources().SysConfig().DataStoreConfig.Name, nic)
-
-	//targets.Activate("admin", "admin", nic)
 	common.WaitForSignal(res)
 }
-
-func startDb(nic ifs.IVNic) {
-	_, user, pass, port, err := nic.Resources().Security().Credential(nic.Resources().SysConfig().DataStoreConfig.Type, nic.Resources().SysConfig().DataStoreConfig.Name, nic.Resources())
-	if err != nil {
-		panic(nic.Resources().SysConfig().DataStoreConfig.Type + " " + nic.Resources().SysConfig().DataStoreConfig.Name + " " + err.Error())
-	}
-	cmd := exec.Command("nohup", "/start-postgres.sh", nic.Resources().SysConfig().DataStoreConfig.Name, user, pass, port)
-	out, err := cmd.Output()
-	if err != nil {
-		panic(err)
-	}
-	fmt.Println(string(out))
-	time.Sleep(time.Second * 5)
-}
@@ -0,0 +1,237 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Exit codes returned by prctl. Automation relies on these to tell a failed
+// request apart from a malformed command line.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// usageError marks an error caused by the command line rather than by the
+// request itself, so main can exit with exitUsage and print the command help.
+type usageError struct {
+	msg string
+}
+
+func (this *usageError) Error() string {
+	return this.msg
+}
+
+func usagef(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// command is one node in the prctl command tree. A command either has
+// subcommands or a run function, never both.
+type command struct {
+	name    string
+	aliases []string
+	args    string
+	summary string
+	flags   func(fs *flag.FlagSet)
+	run     func(s *session, args []string) error
+	subs    []*command
+	parent  *command
+}
+
+func (this *command) add(subs ...*command) *command {
+	for _, sub := range subs {
+		sub.parent = this
+		this.subs = append(this.subs, sub)
+	}
+	return this
+}
+
+func (this *command) sub(name string) *command {
+	for _, sub := range this.subs {
+		if sub.name == name {
+			return sub
+		}
+		for _, alias := range sub.aliases {
+			if alias == name {
+				return sub
+			}
+		}
+	}
+	return nil
+}
+
+func (this *command) path() string {
+	if this.parent == nil {
+		return this.name
+	}
+	return this.parent.path() + " " + this.name
+}
+
+// flagSet builds the flag set for this command. Global flags are registered on
+// every level so they may appear anywhere on the command line.
+func (this *command) flagSet(s *session) *flag.FlagSet {
+	fs := flag.NewFlagSet(this.path(), flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	s.globalFlags(fs)
+	if this.flags != nil {
+		this.flags(fs)
+	}
+	return fs
+}
+
+func (this *command) usage(s *session, w io.Writer) {
+	synopsis := this.path()
+	if len(this.subs) > 0 {
+		synopsis += " <command>"
+	}
+	if this.args != "" {
+		synopsis += " " + this.args
+	}
+	fmt.Fprintln(w, "Usage:", synopsis, "[flags]")
+	if this.summary != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, this.summary)
+	}
+	if len(this.subs) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Commands:")
+		width := 0
+		for _, sub := range this.subs {
+			if len(sub.name) > width {
+				width = len(sub.name)
+			}
+		}
+		for _, sub := range this.subs {
+			fmt.Fprintf(w, "  %-*s  %s\n", width, sub.name, sub.summary)
+		}
+	}
+	fs := this.flagSet(s)
+	fs.SetOutput(w)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	fs.PrintDefaults()
+}
+
+// parseInterspersed parses flags that may appear before, between or after
+// positional arguments and returns the positional arguments in order.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// execute resolves the command addressed by args and runs it, returning the
+// process exit code.
+func execute(root *command, s *session, args []string) int {
+	cmd := root
+	for len(cmd.subs) > 0 {
+		fs := flag.NewFlagSet(cmd.path(), flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		s.globalFlags(fs)
+		err := fs.Parse(args)
+		if errors.Is(err, flag.ErrHelp) {
+			cmd.usage(s, s.out)
+			return exitOK
+		}
+		if err != nil {
+			fmt.Fprintln(s.err, "Error:", err)
+			cmd.usage(s, s.err)
+			return exitUsage
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			cmd.usage(s, s.err)
+			return exitUsage
+		}
+		if args[0] == "help" {
+			target := cmd
+			for _, name := range args[1:] {
+				if target = target.sub(name); target == nil {
+					fmt.Fprintln(s.err, "Error: unknown command", strings.Join(args[1:], " "))
+					return exitUsage
+				}
+			}
+			target.usage(s, s.out)
+			return exitOK
+		}
+		next := cmd.sub(args[0])
+		if next == nil {
+			fmt.Fprintf(s.err, "Error: unknown command \"%s\" for \"%s\"\n", args[0], cmd.path())
+			cmd.usage(s, s.err)
+			return exitUsage
+		}
+		cmd = next
+		args = args[1:]
+	}
+
+	fs := cmd.flagSet(s)
+	positional, err := parseInterspersed(fs, args)
+	if errors.Is(err, flag.ErrHelp) {
+		cmd.usage(s, s.out)
+		return exitOK
+	}
+	if err != nil {
+		fmt.Fprintln(s.err, "Error:", err)
+		cmd.usage(s, s.err)
+		return exitUsage
+	}
+
+	err = cmd.run(s, positional)
+	if err == nil {
+		return exitOK
+	}
+	fmt.Fprintln(s.err, "Error:", err)
+	var ue *usageError
+	if errors.As(err, &ue) {
+		cmd.usage(s, s.err)
+		return exitUsage
+	}
+	return exitError
+}
+
+// exactArgs and the helpers below validate positional arguments for a command.
+func exactArgs(args []string, names ...string) error {
+	if len(args) != len(names) {
+		return usagef("expected %d argument(s) <%s>, got %d", len(names), strings.Join(names, "> <"), len(args))
+	}
+	return nil
+}
+
+func noArgs(args []string) error {
+	if len(args) != 0 {
+		return usagef("unexpected argument(s): %s", strings.Join(args, " "))
+	}
+	return nil
+}
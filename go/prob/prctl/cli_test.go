@@ -0,0 +1,97 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+)
+
+// testTree builds a small command tree whose leaves record what they were
+// called with, so the parser can be exercised without a probler endpoint.
+func testTree(got *[]string, limit *int) *command {
+	root := &command{name: "prctl"}
+	get := &command{name: "get"}
+	get.add(&command{name: "device", aliases: []string{"dev"}, args: "<id>",
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(limit, "limit", 0, "")
+		},
+		run: func(s *session, args []string) error {
+			if err := exactArgs(args, "id"); err != nil {
+				return err
+			}
+			*got = args
+			return nil
+		}})
+	root.add(get, &command{name: "fail", run: func(s *session, args []string) error {
+		return errors.New("request failed")
+	}})
+	return root
+}
+
+func run(args ...string) (int, []string, int, *session) {
+	var got []string
+	limit := 0
+	s := &session{out: &bytes.Buffer{}, err: &bytes.Buffer{}}
+	code := execute(testTree(&got, &limit), s, args)
+	return code, got, limit, s
+}
+
+func TestExecute(t *testing.T) {
+	code, got, limit, s := run("-o", "json", "get", "dev", "--limit", "5", "r1", "--host", "h1")
+	if code != exitOK || len(got) != 1 || got[0] != "r1" || limit != 5 {
+		t.Fatal("unexpected result", code, got, limit)
+	}
+	if s.format != "json" || s.host != "h1" {
+		t.Fatal("global flags not parsed on every level", s.format, s.host)
+	}
+	if code, got, _, _ = run("get", "device", "--", "-r1"); code != exitOK || got[0] != "-r1" {
+		t.Fatal("expected arguments after -- to be positional", code, got)
+	}
+}
+
+func TestExecuteExitCodes(t *testing.T) {
+	cases := []struct {
+		args []string
+		code int
+		err  string
+	}{
+		{[]string{}, exitUsage, ""},
+		{[]string{"nope"}, exitUsage, `unknown command "nope" for "prctl"`},
+		{[]string{"get", "device"}, exitUsage, "expected 1 argument(s) <id>, got 0"},
+		{[]string{"get", "device", "r1", "--bogus"}, exitUsage, "flag provided but not defined"},
+		{[]string{"fail"}, exitError, "request failed"},
+		{[]string{"help", "get", "device"}, exitOK, ""},
+		{[]string{"get", "device", "-h"}, exitOK, ""},
+		{[]string{"help", "nope"}, exitUsage, "unknown command nope"},
+	}
+	for _, c := range cases {
+		code, _, _, s := run(c.args...)
+		if code != c.code {
+			t.Fatal(c.args, "exited", code, "expected", c.code)
+		}
+		if stderr := s.err.(*bytes.Buffer).String(); !strings.Contains(stderr, c.err) {
+			t.Fatal(c.args, "expected", c.err, "in", stderr)
+		}
+	}
+	_, _, _, s := run("help", "get", "device")
+	if usage := s.out.(*bytes.Buffer).String(); !strings.Contains(usage, "Usage: prctl get device <id> [flags]") {
+		t.Fatal("unexpected usage", usage)
+	}
+}
@@ -0,0 +1,787 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+	"github.com/saichler/probler/go/prob/common/commands"
+	"github.com/saichler/probler/go/prob/common/creates"
+	"github.com/saichler/probler/go/prob/common/output"
+	types3 "github.com/saichler/probler/go/types"
+)
+
+// rootCommand builds the full prctl command tree.
+func rootCommand() *command {
+	root := &command{name: "prctl", summary: "Probler control CLI"}
+	root.add(
+		getCommand(),
+		addCommand(),
+		deleteCommand(),
+		updateCommand(),
+		stateCommand("enable", l8tpollaris.L8PTargetState_Up),
+		stateCommand("disable", l8tpollaris.L8PTargetState_Down),
+		topCommand(),
+		logsCommand(),
+		describeCommand(),
+		execCommand(),
+		importCommand(),
+		fleetCommand(),
+		debugCommand(),
+	)
+	return root
+}
+
+func getCommand() *command {
+	get := &command{name: "get", summary: "Retrieve objects"}
+	get.add(
+		&command{name: "cluster", args: "<name>", summary: "Get a K8s cluster from the cluster cache",
+			run: func(s *session, args []string) error {
+				if err := exactArgs(args, "name"); err != nil {
+					return err
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return s.print(commands.GetCluster(rc, s.resources, args[0]))
+			}},
+		&command{name: "ocluster", args: "<name>", summary: "Get a single K8s cluster record",
+			run: func(s *session, args []string) error {
+				if err := exactArgs(args, "name"); err != nil {
+					return err
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return s.print(commands.GetClusterOrm(rc, s.resources, args[0]))
+			}},
+		&command{name: "device", args: "<ip>", summary: "Get a network device",
+			run: func(s *session, args []string) error {
+				if err := exactArgs(args, "ip"); err != nil {
+					return err
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return s.print(commands.GetDevice(rc, s.resources, args[0]))
+			}},
+		&command{name: "health", summary: "Get the health of every process",
+			run: func(s *session, args []string) error {
+				if err := noArgs(args); err != nil {
+					return err
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return s.print(commands.GetHealth(rc, s.resources))
+			}},
+		getTargetsCommand(),
+		getTopoCommand(),
+		getLinksCommand(),
+		getClusterHistoryCommand(),
+	)
+	for _, kind := range commands.K8sKinds {
+		get.add(getK8sCommand(kind))
+	}
+	return get
+}
+
+func getTargetsCommand() *command {
+	sel := &commands.TargetSelector{}
+	return &command{name: "targets", aliases: []string{"target"}, args: "[target-id...]",
+		summary: "List collection targets",
+		flags:   func(fs *flag.FlagSet) { selectorFlags(fs, sel) },
+		run: func(s *session, args []string) error {
+			sel.Ids = args
+			rc, err := s.client()
+			if err != nil {
+				return err
+			}
+			if sel.Empty() {
+				return s.print(commands.ListTargets("", "", rc, s.resources))
+			}
+			selected, err := commands.SelectTargets(sel, rc, s.resources)
+			return s.print(&l8tpollaris.L8PTargetList{List: selected}, err)
+		}}
+}
+
+// getK8sCommand builds "get <kind>" for one K8s/Istio prime object.
+func getK8sCommand(kind *commands.K8sKind) *command {
+	var cluster, namespace, where string
+	scope := "cluster scoped"
+	if kind.Namespaced {
+		scope = "namespaced"
+	}
+	return &command{name: kind.Name, aliases: kind.Aliases,
+		summary: "Get " + kind.Name + " (" + kind.LinksId + ", " + scope + ")",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&cluster, "cluster", "", "cluster name")
+			fs.StringVar(&namespace, "namespace", "", "namespace (namespaced kinds only)")
+			fs.StringVar(&where, "where", "", "additional L8QL where expression, e.g. 'Name=coredns*'")
+		},
+		run: func(s *session, args []string) error {
+			if err := noArgs(args); err != nil {
+				return err
+			}
+			if namespace != "" && !kind.Namespaced {
+				return usagef("%s are cluster scoped, --namespace does not apply", kind.Name)
+			}
+			rc, err := s.client()
+			if err != nil {
+				return err
+			}
+			return s.print(commands.GetK8s(kind, cluster, namespace, where, rc, s.resources))
+		}}
+}
+
+func addCommand() *command {
+	add := &command{name: "add", summary: "Add targets and poll configurations"}
+	add.add(
+		&command{name: "polls", summary: "Add the boot pollaris models",
+			run: func(s *session, args []string) error {
+				if err := noArgs(args); err != nil {
+					return err
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return commands.AddPollConfigs(rc, s.resources)
+			}},
+		&command{name: "device", args: "<ip>", summary: "Add a network device target",
+			run: func(s *session, args []string) error {
+				if err := exactArgs(args, "ip"); err != nil {
+					return err
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return commands.AddDevice(args[0], rc, s.resources)
+			}},
+		&command{name: "gpu", args: "<ip>", summary: "Add a GPU target",
+			run: func(s *session, args []string) error {
+				if err := exactArgs(args, "ip"); err != nil {
+					return err
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return commands.AddGPU(args[0], rc, s.resources)
+			}},
+		&command{name: "devices", args: "<preset[,preset...]>", summary: "Add simulator fleet presets, see fleet up",
+			run: func(s *session, args []string) error {
+				if err := exactArgs(args, "preset"); err != nil {
+					return err
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return commands.AddDevices(args[0], rc, s.resources)
+			}},
+		&command{name: "cluster", args: "<name>", summary: "Add a K8s cluster target",
+			run: func(s *session, args []string) error {
+				if err := exactArgs(args, "name"); err != nil {
+					return err
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return commands.AddCluster(args[0], rc, s.resources)
+			}},
+	)
+	return add
+}
+
+func deleteCommand() *command {
+	del := &command{name: "delete", summary: "Delete targets"}
+	sel := &commands.TargetSelector{}
+	del.add(
+		&command{name: "target", aliases: []string{"targets"}, args: "[target-id...]",
+			summary: "Delete targets by id, links id or IP range",
+			flags:   func(fs *flag.FlagSet) { selectorFlags(fs, sel) },
+			run: func(s *session, args []string) error {
+				sel.Ids = args
+				if sel.Empty() {
+					return usagef("no targets selected")
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return commands.DeleteTargets(sel, rc, s.resources)
+			}},
+	)
+	return del
+}
+
+// selectorFlags registers the bulk target selection flags.
+func selectorFlags(fs *flag.FlagSet, sel *commands.TargetSelector) {
+	fs.StringVar(&sel.LinksId, "links", "", "select targets with this links id, e.g. NetDev")
+	fs.StringVar(&sel.Range, "range", "", "select targets by IP, CIDR (10.0.0.0/24) or range (10.0.0.1-10.0.0.50)")
+	fs.StringVar(&sel.Where, "where", "", "select targets with an L8QL where expression")
+}
+
+func updateCommand() *command {
+	update := &command{name: "update", summary: "Update targets"}
+	sel := &commands.TargetSelector{}
+	change := &commands.TargetUpdate{}
+	update.add(
+		&command{name: "target", aliases: []string{"targets"}, args: "[target-id...]",
+			summary: "Change host protocol settings of targets",
+			flags: func(fs *flag.FlagSet) {
+				selectorFlags(fs, sel)
+				fs.StringVar(&change.Protocol, "protocol", "", "host protocol to change: ssh|snmp|rest|k8s|kubectl")
+				fs.StringVar(&change.Addr, "addr", "", "protocol address")
+				fs.IntVar(&change.Port, "protocol-port", 0, "protocol port")
+				fs.StringVar(&change.CredId, "cred", "", "credentials id")
+				fs.IntVar(&change.Timeout, "timeout", 0, "protocol timeout in seconds")
+			},
+			run: func(s *session, args []string) error {
+				sel.Ids = args
+				if sel.Empty() {
+					return usagef("no targets selected")
+				}
+				if change.Protocol == "" {
+					return usagef("missing --protocol")
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return commands.UpdateTargets(sel, change, rc, s.resources)
+			}},
+	)
+	return update
+}
+
+// stateCommand builds "enable target" and "disable target".
+func stateCommand(name string, state l8tpollaris.L8PTargetState) *command {
+	group := &command{name: name, summary: "Set targets " + state.String()}
+	sel := &commands.TargetSelector{}
+	group.add(
+		&command{name: "target", aliases: []string{"targets"}, args: "[target-id...]",
+			summary: "Set targets " + state.String() + " by id, links id or IP range",
+			flags:   func(fs *flag.FlagSet) { selectorFlags(fs, sel) },
+			run: func(s *session, args []string) error {
+				sel.Ids = args
+				if sel.Empty() {
+					return usagef("no targets selected")
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return commands.SetTargetsState(sel, state, rc, s.resources)
+			}},
+	)
+	return group
+}
+
+func topCommand() *command {
+	var watch bool
+	var interval, count int
+	var sortBy, filter string
+	return &command{name: "top", summary: "Show per-process bus statistics",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&watch, "watch", false, "refresh in place until interrupted")
+			fs.IntVar(&interval, "interval", 2, "seconds between refreshes in watch mode")
+			fs.IntVar(&count, "count", 0, "stop watching after this many refreshes, 0 for no limit")
+			fs.StringVar(&sortBy, "sort", commands.TopSortMem, "sort by tx, rx, mem, cpu or alias; tx and rx sort by msgs/sec once known")
+			fs.StringVar(&filter, "filter", "", "only show processes whose alias matches this regex")
+		},
+		run: func(s *session, args []string) error {
+			if err := noArgs(args); err != nil {
+				return err
+			}
+			if interval <= 0 {
+				return usagef("--interval must be positive")
+			}
+			view, err := commands.NewTopView(sortBy, filter)
+			if err != nil {
+				return usagef("%s", err.Error())
+			}
+			rc, err := s.client()
+			if err != nil {
+				return err
+			}
+			p, err := s.printer()
+			if err != nil {
+				return err
+			}
+			render := func() error {
+				top, err := commands.Top(rc, s.resources)
+				if err != nil {
+					return err
+				}
+				switch p.Format() {
+				case output.Table:
+					if watch {
+						fmt.Fprint(s.out, "\033[H\033[2J")
+						fmt.Fprintf(s.out, "Every %ds, sorted by %s    %s\n\n", interval, sortBy, time.Now().Format("15:04:05"))
+					}
+					fmt.Fprint(s.out, view.Render(top, time.Now()))
+					return nil
+				case output.Wide:
+					if watch {
+						fmt.Fprint(s.out, "\033[H\033[2J")
+					}
+					fmt.Fprintln(s.out, commands.FormatTop(view.Filter(top)))
+					return nil
+				}
+				return p.Print(view.Filter(top))
+			}
+			if !watch {
+				return render()
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			ticker := time.NewTicker(time.Duration(interval) * time.Second)
+			defer ticker.Stop()
+			for i := 1; ; i++ {
+				if err = render(); err != nil {
+					return err
+				}
+				if count > 0 && i >= count {
+					return nil
+				}
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+				}
+			}
+		}}
+}
+
+func getTopoCommand() *command {
+	var layer, export string
+	var list bool
+	return &command{name: "topo", aliases: []string{"topology"}, summary: "Get or export a topology",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&layer, "type", "l1", "topology to fetch: l1, l2, l3, k8s or a catalog name")
+			fs.StringVar(&export, "export", "", "write the graph as dot, graphml or json instead of printing it")
+			fs.BoolVar(&list, "list", false, "list the available topologies")
+		},
+		run: func(s *session, args []string) error {
+			if err := noArgs(args); err != nil {
+				return err
+			}
+			switch export {
+			case "", commands.TopoDot, commands.TopoGraphML, commands.TopoJSON:
+			default:
+				return usagef("unknown export format %q, expected dot, graphml or json", export)
+			}
+			rc, err := s.client()
+			if err != nil {
+				return err
+			}
+			if list {
+				return s.print(commands.ListTopologies(rc, s.resources))
+			}
+			topo, err := commands.GetTopo(layer, rc, s.resources)
+			if err != nil {
+				return err
+			}
+			if export == "" {
+				return s.print(topo, nil)
+			}
+			data, err := commands.ExportTopo(layer, topo, export)
+			if err != nil {
+				return err
+			}
+			_, err = s.out.Write(data)
+			return err
+		}}
+}
+
+func getLinksCommand() *command {
+	return &command{name: "links", aliases: []string{"link"}, args: "[links-id...]",
+		summary: "Show which processes host the collector, parser, cache and persist of each links id",
+		run: func(s *session, args []string) error {
+			rc, err := s.client()
+			if err != nil {
+				return err
+			}
+			p, err := s.printer()
+			if err != nil {
+				return err
+			}
+			routes, err := commands.GetLinks(args, rc, s.resources)
+			if err != nil {
+				return err
+			}
+			columns, rows := commands.LinksRows(routes, p.Format() != output.Table)
+			return p.PrintRows(columns, rows, routes)
+		}}
+}
+
+// getClusterHistoryCommand reads the summary history adcon records, from the
+// cluster history service.
+func getClusterHistoryCommand() *command {
+	var since time.Duration
+	var from, to, metrics string
+	return &command{name: "cluster-history", args: "<cluster>",
+		summary: "Show the recorded summary counters of a K8s cluster over time",
+		flags: func(fs *flag.FlagSet) {
+			fs.DurationVar(&since, "since", time.Hour, "how far back from --to")
+			fs.StringVar(&from, "from", "", "start of the range (RFC3339), overrides --since")
+			fs.StringVar(&to, "to", "", "end of the range (RFC3339), default now")
+			fs.StringVar(&metrics, "metric", "", "comma separated metrics, e.g. ready_nodes,failed_pods")
+		},
+		run: func(s *session, args []string) error {
+			if err := exactArgs(args, "cluster"); err != nil {
+				return err
+			}
+			end := time.Now()
+			if to != "" {
+				t, err := time.Parse(time.RFC3339, to)
+				if err != nil {
+					return usagef("--to: %s", err.Error())
+				}
+				end = t
+			}
+			start := end.Add(-since)
+			if from != "" {
+				t, err := time.Parse(time.RFC3339, from)
+				if err != nil {
+					return usagef("--from: %s", err.Error())
+				}
+				start = t
+			}
+			names := make([]string, 0)
+			if metrics != "" {
+				names = strings.Split(metrics, ",")
+			}
+			rc, err := s.client()
+			if err != nil {
+				return err
+			}
+			p, err := s.printer()
+			if err != nil {
+				return err
+			}
+			series, err := commands.GetClusterHistory(rc, args[0], start, end, names)
+			if err != nil {
+				return err
+			}
+			columns, rows := commands.ClusterHistoryRows(series, p.Format() != output.Table)
+			return p.PrintRows(columns, rows, series)
+		}}
+}
+
+func logsCommand() *command {
+	opts := &commands.LogsOptions{}
+	return &command{name: "logs", args: "<cluster>/<namespace>/<pod>", summary: "Fetch the whole log of a pod",
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(&opts.Tail, "tail", 0, "print only the last N lines of the fetched log, 0 for all")
+			fs.StringVar(&opts.Container, "container", "", "not supported, the logs job takes no container")
+			fs.StringVar(&opts.Since, "since", "", "not supported, the logs job takes no time range")
+		},
+		run: func(s *session, args []string) error {
+			if err := exactArgs(args, "cluster/namespace/pod"); err != nil {
+				return err
+			}
+			if err := opts.Validate(); err != nil {
+				return usagef("%s", err.Error())
+			}
+			rc, err := s.client()
+			if err != nil {
+				return err
+			}
+			logs, err := commands.Logs(rc, args[0], opts, s.resources)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(s.out, logs)
+			return nil
+		}}
+}
+
+func describeCommand() *command {
+	return &command{name: "describe", args: "<kind> <cluster>/[namespace/]<name>",
+		summary: "Describe a K8s object of any kind, with kubectl describe output where the collector has a detail job",
+		run: func(s *session, args []string) error {
+			if err := exactArgs(args, "kind", "cluster/[namespace/]name"); err != nil {
+				return err
+			}
+			rc, err := s.client()
+			if err != nil {
+				return err
+			}
+			out, obj, err := commands.Describe(args[0], args[1], rc, s.resources)
+			if err != nil {
+				return err
+			}
+			if obj != nil {
+				if s.format == "" {
+					s.format = string(output.YAML)
+				}
+				return s.print(obj, nil)
+			}
+			fmt.Fprint(s.out, out)
+			return nil
+		}}
+}
+
+func execCommand() *command {
+	var filename string
+	return &command{name: "exec", summary: "Run a CJob read from a protojson file",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&filename, "f", "", "CJob protojson file")
+		},
+		run: func(s *session, args []string) error {
+			if err := noArgs(args); err != nil {
+				return err
+			}
+			if filename == "" {
+				return usagef("missing -f <file>")
+			}
+			rc, err := s.client()
+			if err != nil {
+				return err
+			}
+			return commands.Exec(filename, rc, s.resources)
+		}}
+}
+
+func importCommand() *command {
+	imp := &command{name: "import", summary: "Import objects from files"}
+	var filename string
+	var chunk int
+	var dryRun bool
+	imp.add(
+		&command{name: "targets", summary: "Import device and GPU targets from a YAML, JSON or CSV inventory",
+			flags: func(fs *flag.FlagSet) {
+				fs.StringVar(&filename, "f", "", "inventory file (.yaml, .json or .csv)")
+				fs.IntVar(&chunk, "chunk", 500, "targets posted per request")
+				fs.BoolVar(&dryRun, "dry-run", false, "validate the inventory without posting")
+			},
+			run: func(s *session, args []string) error {
+				if err := noArgs(args); err != nil {
+					return err
+				}
+				if filename == "" {
+					return usagef("missing -f <file>")
+				}
+				if dryRun {
+					return commands.ImportTargets(filename, chunk, true, nil, s.resources)
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return commands.ImportTargets(filename, chunk, false, rc, s.resources)
+			}},
+	)
+	return imp
+}
+
+func fleetCommand() *command {
+	fleet := &command{name: "fleet", summary: "Bring simulator fleets up and down"}
+	var filename, preset string
+	var seed int64
+	var chunk int
+	var dryRun bool
+	specFlags := func(fs *flag.FlagSet) {
+		fs.StringVar(&filename, "f", "", "fleet spec file (.yaml or .json)")
+		fs.StringVar(&preset, "preset", "", "comma separated presets: "+strings.Join(creates.FleetPresetNames(), ", "))
+		fs.Int64Var(&seed, "seed", 0, "override the seed of the spec")
+	}
+	loadSpec := func() (*creates.FleetSpec, error) {
+		var spec *creates.FleetSpec
+		var err error
+		switch {
+		case filename != "" && preset != "":
+			return nil, usagef("give either -f or --preset, not both")
+		case filename != "":
+			spec, err = commands.LoadFleetSpec(filename)
+		case preset != "":
+			spec, err = creates.FleetPreset(preset)
+		default:
+			return nil, usagef("missing -f <file> or --preset <name>")
+		}
+		if err == nil && seed != 0 {
+			spec.Seed = seed
+		}
+		return spec, err
+	}
+	fleet.add(
+		&command{name: "up", summary: "Generate a fleet and add its targets",
+			flags: func(fs *flag.FlagSet) {
+				specFlags(fs)
+				fs.IntVar(&chunk, "chunk", 500, "targets posted per request")
+				fs.BoolVar(&dryRun, "dry-run", false, "generate the fleet without posting")
+			},
+			run: func(s *session, args []string) error {
+				if err := noArgs(args); err != nil {
+					return err
+				}
+				spec, err := loadSpec()
+				if err != nil {
+					return err
+				}
+				if dryRun {
+					return commands.FleetUp(spec, chunk, true, nil, s.resources)
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return commands.FleetUp(spec, chunk, false, rc, s.resources)
+			}},
+		&command{name: "down", summary: "Delete every target of a fleet",
+			flags: specFlags,
+			run: func(s *session, args []string) error {
+				if err := noArgs(args); err != nil {
+					return err
+				}
+				spec, err := loadSpec()
+				if err != nil {
+					return err
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				return commands.FleetDown(spec, rc, s.resources)
+			}},
+		&command{name: "show", summary: "Print the targets a fleet generates",
+			flags: specFlags,
+			run: func(s *session, args []string) error {
+				if err := noArgs(args); err != nil {
+					return err
+				}
+				spec, err := loadSpec()
+				if err != nil {
+					return err
+				}
+				return s.print(commands.FleetTargets(spec))
+			}},
+	)
+	return fleet
+}
+
+// debugCommand sends controls to the debug service every process runs on the
+// bus, addressed by the alias prctl top shows, or all for every process.
+func debugCommand() *command {
+	debug := &command{name: "debug", summary: "Change log levels, trace links ids and dump a running service"}
+	var component, file string
+	var off bool
+	debug.add(
+		&command{name: "level", args: "<alias|all> <level>",
+			summary: "Set the log level: trace, debug, info, warning or error",
+			flags: func(fs *flag.FlagSet) {
+				fs.StringVar(&component, "component", "", "only this component (resources alias), e.g. parser in allinone")
+			},
+			run: func(s *session, args []string) error {
+				if err := exactArgs(args, "alias|all", "level"); err != nil {
+					return err
+				}
+				ctl, err := commands.DebugLogLevel(debugAlias(args[0]), component, args[1])
+				if err != nil {
+					return usagef("%s", err.Error())
+				}
+				return s.debug(ctl)
+			}},
+		&command{name: "trace", args: "<alias|all> [links-id]",
+			summary: "Log every route lookup of a links id's CJobs, or list the traced links ids",
+			flags: func(fs *flag.FlagSet) {
+				fs.BoolVar(&off, "off", false, "stop tracing the links id")
+			},
+			run: func(s *session, args []string) error {
+				if len(args) < 1 || len(args) > 2 {
+					return usagef("expected <alias|all> [links-id]")
+				}
+				linkid := ""
+				if len(args) == 2 {
+					linkid = args[1]
+				}
+				ctl, err := commands.DebugTrace(debugAlias(args[0]), linkid, off)
+				if err != nil {
+					return usagef("%s", err.Error())
+				}
+				return s.debug(ctl)
+			}},
+		&command{name: "dump", args: "<alias> <goroutines|heap>",
+			summary: "Fetch a goroutine dump or a heap profile (go tool pprof format)",
+			flags: func(fs *flag.FlagSet) {
+				fs.StringVar(&file, "f", "", "write the dump to this file instead of stdout")
+			},
+			run: func(s *session, args []string) error {
+				if err := exactArgs(args, "alias", "goroutines|heap"); err != nil {
+					return err
+				}
+				ctl, err := commands.DebugDump(debugAlias(args[0]), args[1])
+				if err != nil {
+					return usagef("%s", err.Error())
+				}
+				rc, err := s.client()
+				if err != nil {
+					return err
+				}
+				replies, err := commands.Debug(ctl, rc, s.resources)
+				if err != nil {
+					return err
+				}
+				if file != "" {
+					return os.WriteFile(file, replies[0].Dump, 0644)
+				}
+				_, err = s.out.Write(replies[0].Dump)
+				return err
+			}},
+	)
+	return debug
+}
+
+// debugAlias maps all to the empty alias, every process.
+func debugAlias(arg string) string {
+	if arg == "all" {
+		return ""
+	}
+	return arg
+}
+
+// debug sends a control and prints one line per process it reached.
+func (this *session) debug(ctl *types3.DebugControl) error {
+	rc, err := this.client()
+	if err != nil {
+		return err
+	}
+	replies, err := commands.Debug(ctl, rc, this.resources)
+	for _, reply := range replies {
+		if reply.Error == "" {
+			fmt.Fprintln(this.out, reply.Alias+": "+reply.Message)
+		}
+	}
+	return err
+}
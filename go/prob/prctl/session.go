@@ -0,0 +1,250 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8web/go/web/client"
+	"github.com/saichler/probler/go/prob/common/output"
+	"google.golang.org/protobuf/proto"
+)
+
+// Environment variables consulted when the matching flag is not set.
+const (
+	envHost     = "PRCTL_HOST"
+	envPort     = "PRCTL_PORT"
+	envUser     = "PRCTL_USER"
+	envPassword = "PRCTL_PASSWORD"
+	envProfile  = "PRCTL_PROFILE"
+	envConfig   = "PRCTL_CONFIG"
+)
+
+// profile is one named connection entry in the prctl config file.
+type profile struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port,omitempty"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+// profileConfig is the on-disk layout of ~/.prctl.json (or $PRCTL_CONFIG):
+//
+//	{"current": "lab", "profiles": {"lab": {"host": "10.0.0.1", "user": "operator", "password": "..."}}}
+type profileConfig struct {
+	Current  string              `json:"current"`
+	Profiles map[string]*profile `json:"profiles"`
+}
+
+// session carries the global options and lazily connects to the probler web
+// endpoint the first time a command needs it.
+type session struct {
+	resources ifs.IResources
+	out       io.Writer
+	err       io.Writer
+
+	host     string
+	port     int
+	user     string
+	password string
+	profile  string
+
+	format  string
+	columns string
+
+	rc *client.RestClient
+}
+
+func newSession(resources ifs.IResources) *session {
+	return &session{resources: resources, out: os.Stdout, err: os.Stderr}
+}
+
+func (this *session) globalFlags(fs *flag.FlagSet) {
+	fs.StringVar(&this.host, "host", this.host, "probler web host (env "+envHost+")")
+	fs.IntVar(&this.port, "port", this.port, "probler web port (env "+envPort+", default from sysconfig)")
+	fs.StringVar(&this.user, "user", this.user, "user name (env "+envUser+")")
+	fs.StringVar(&this.password, "password", this.password, "password (env "+envPassword+")")
+	fs.StringVar(&this.profile, "profile", this.profile, "connection profile from the prctl config file (env "+envProfile+")")
+	fs.StringVar(&this.format, "o", this.format, "output format: "+output.Formats)
+	fs.StringVar(&this.columns, "columns", this.columns, "comma separated columns for table/wide/csv output, e.g. Id,Equipmentinfo.SysName")
+}
+
+// printer returns the output printer selected by -o and --columns.
+func (this *session) printer() (*output.Printer, error) {
+	format, err := output.ParseFormat(this.format)
+	if err != nil {
+		return nil, usagef("%s", err.Error())
+	}
+	var columns []string
+	for _, column := range strings.Split(this.columns, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return output.NewPrinter(format, columns, this.resources, this.out), nil
+}
+
+// print renders a command response in the selected output format.
+func (this *session) print(msg proto.Message, err error) error {
+	if err != nil {
+		return err
+	}
+	p, err := this.printer()
+	if err != nil {
+		return err
+	}
+	return p.Print(msg)
+}
+
+// resolve fills any connection option not given as a flag from the
+// environment first and then from the selected profile.
+func (this *session) resolve() error {
+	if this.host == "" {
+		this.host = os.Getenv(envHost)
+	}
+	if this.port == 0 {
+		if p := os.Getenv(envPort); p != "" {
+			port, err := strconv.Atoi(p)
+			if err != nil {
+				return fmt.Errorf("invalid %s value %q", envPort, p)
+			}
+			this.port = port
+		}
+	}
+	if this.user == "" {
+		this.user = os.Getenv(envUser)
+	}
+	if this.password == "" {
+		this.password = os.Getenv(envPassword)
+	}
+	if this.profile == "" {
+		this.profile = os.Getenv(envProfile)
+	}
+
+	if this.host == "" || this.user == "" || this.password == "" {
+		p, err := this.loadProfile()
+		if err != nil {
+			return err
+		}
+		if p != nil {
+			if this.host == "" {
+				this.host = p.Host
+			}
+			if this.port == 0 {
+				this.port = p.Port
+			}
+			if this.user == "" {
+				this.user = p.User
+			}
+			if this.password == "" {
+				this.password = p.Password
+			}
+		}
+	}
+
+	if this.host == "" {
+		return usagef("no host: use --host, %s or a profile", envHost)
+	}
+	if this.user == "" || this.password == "" {
+		return usagef("no credentials: use --user/--password, %s/%s or a profile", envUser, envPassword)
+	}
+	if this.port == 0 {
+		this.port = int(this.resources.SysConfig().WebConfig.WebPort)
+	}
+	return nil
+}
+
+func (this *session) loadProfile() (*profile, error) {
+	filename := os.Getenv(envConfig)
+	if filename == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, nil
+		}
+		filename = filepath.Join(home, ".prctl.json")
+	}
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		if this.profile != "" {
+			return nil, fmt.Errorf("profile %q requested but %s does not exist", this.profile, filename)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cfg := &profileConfig{}
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", filename, err.Error())
+	}
+	name := this.profile
+	if name == "" {
+		name = cfg.Current
+	}
+	if name == "" {
+		return nil, nil
+	}
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found in %s", name, filename)
+	}
+	return p, nil
+}
+
+// client returns an authenticated rest client, connecting on first use.
+func (this *session) client() (*client.RestClient, error) {
+	if this.rc != nil {
+		return this.rc, nil
+	}
+	if err := this.resolve(); err != nil {
+		return nil, err
+	}
+	clientConfig := &client.RestClientConfig{
+		Host:          this.host,
+		Port:          this.port,
+		Https:         true,
+		Prefix:        this.resources.SysConfig().WebConfig.EndPointPrefix,
+		TokenRequired: true,
+		AuthInfo: &client.RestAuthInfo{
+			IsAPIKey:   false,
+			NeedAuth:   true,
+			BodyType:   "AuthUser",
+			UserField:  "User",
+			PassField:  "Pass",
+			RespType:   "AuthToken",
+			TokenField: "Token",
+			AuthPath:   "/auth",
+		},
+	}
+	rc, err := client.NewRestClient(clientConfig, this.resources)
+	if err != nil {
+		return nil, err
+	}
+	if err = rc.Auth(this.user, this.password); err != nil {
+		return nil, fmt.Errorf("authentication as %s failed: %s", this.user, err.Error())
+	}
+	this.rc = rc
+	return rc, nil
+}
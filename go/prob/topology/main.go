@@ -17,21 +17,21 @@ package main
 
 import (
 	"github.com/saichler/l8bus/go/overlay/vnic"
-	"github.com/saichler/l8topology/go/topo/discover"
-	"github.com/saichler/l8topology/go/topo/topo_list"
 	"github.com/saichler/l8types/go/ifs"
 	"github.com/saichler/probler/go/prob/common"
+	"github.com/saichler/probler/go/prob/common/activate"
+	"os"
 )
 
 func main() {
-	resources := common.CreateResources("topo")
+	resources := common.CreateResources("topo", nil, os.Args[1:]...)
 	ifs.SetNetworkMode(ifs.NETWORK_K8s)
 	nic := vnic.NewVirtualNetworkInterface(resources, nil)
 	nic.Start()
+	activate.Vnic(nic)
 	nic.WaitForConnection()
 
-	topo_list.Activate(nic)
-	discover.ActivateLayer1(nic)
+	activate.Topology(nic)
 
 	common.WaitForSignal(resources)
 }
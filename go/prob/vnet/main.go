@@ -16,6 +16,7 @@
 package main
 
 import (
+	"context"
 	"os"
 
 	"github.com/saichler/l8bus/go/overlay/vnet"
@@ -23,9 +24,13 @@ import (
 )
 
 func main() {
-	resources := common2.CreateResources("vnet-" + os.Getenv("HOSTNAME"))
+	resources := common2.CreateResources("vnet-"+os.Getenv("HOSTNAME"), nil, os.Args[1:]...)
 	net := vnet.NewVNet(resources)
 	net.Start()
+	common2.OnShutdown(common2.ShutdownNetwork, "vnet", func(ctx context.Context) error {
+		net.Shutdown()
+		return nil
+	})
 	resources.Logger().Info("vnet started!")
 
 	common2.WaitForSignal(resources)
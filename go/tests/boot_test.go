@@ -0,0 +1,112 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/saichler/probler/go/prob/common"
+)
+
+// bootDefaults returns the defaults layer of the layering tests.
+func bootDefaults(groups ...string) *common.BootConfig {
+	defaults := common.NewBootConfig(groups...)
+	defaults.LogLevel = "info"
+	defaults.KeepAliveSeconds = 30
+	defaults.ShutdownSeconds = 30
+	defaults.VnetHost = "default-host"
+	defaults.VnetPort = 1000
+	defaults.TxQueueSize = 10
+	return defaults
+}
+
+func writeBootFile(t *testing.T, content string) string {
+	filename := filepath.Join(t.TempDir(), "probler.yaml")
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+// TestBootLayering checks defaults < file < env < flags, one setting per
+// layer boundary.
+func TestBootLayering(t *testing.T) {
+	filename := writeBootFile(t, "keepAliveSeconds: 20\nvnetHost: file-host\nvnetPort: 2000\ntxQueueSize: 20\n")
+	t.Setenv(common.BootConfigEnv, filename)
+	t.Setenv("PROBLER_VNET_HOST", "env-host")
+	t.Setenv("PROBLER_VNET_PORT", "3000")
+
+	boot, err := common.LoadBootConfig(bootDefaults(), []string{"--vnet-port", "4000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if boot.LogLevel != "info" || boot.ShutdownSeconds != 30 {
+		t.Fatal("defaults not kept, got", boot.LogLevel, boot.ShutdownSeconds)
+	}
+	if boot.KeepAliveSeconds != 20 || boot.TxQueueSize != 20 {
+		t.Fatal("file did not override the defaults, got", boot.KeepAliveSeconds, boot.TxQueueSize)
+	}
+	if boot.VnetHost != "env-host" {
+		t.Fatal("env did not override the file, got", boot.VnetHost)
+	}
+	if boot.VnetPort != 4000 {
+		t.Fatal("flag did not override the env, got", boot.VnetPort)
+	}
+	out := boot.String()
+	for _, want := range []string{"keepAliveSeconds: 20 (" + filename + ")",
+		"vnetHost: env-host (env PROBLER_VNET_HOST)", "vnetPort: 4000 (flag --vnet-port)"} {
+		if !strings.Contains(out, want) {
+			t.Fatal("expected", want, "in", out)
+		}
+	}
+}
+
+// TestBootGroups checks a binary parses the settings of the groups it
+// registered only.
+func TestBootGroups(t *testing.T) {
+	filename := writeBootFile(t, "kubeconfig: /etc/kube\ndbHost: file-db\n")
+	t.Setenv(common.BootConfigEnv, filename)
+	t.Setenv("PROBLER_DB_START_SECONDS", "5")
+
+	boot, err := common.LoadBootConfig(bootDefaults(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if boot.Kubeconfig != "" || boot.DbHost != "" || boot.DbStartSeconds != 0 {
+		t.Fatal("settings of unregistered groups applied:", boot.String())
+	}
+	if _, err = common.LoadBootConfig(bootDefaults(), []string{"--db-host", "x"}); err == nil {
+		t.Fatal("expected --db-host to be unknown without the db group")
+	}
+
+	boot, err = common.LoadBootConfig(bootDefaults(common.BootDb), []string{"--db-host", "flag-db"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if boot.DbHost != "flag-db" || boot.DbStartSeconds != 5 || boot.Kubeconfig != "" {
+		t.Fatal("db group not parsed alone:", boot.String())
+	}
+	boot, err = common.LoadBootConfig(bootDefaults(common.BootDb), []string{"--db-ssl-mode", "Require"})
+	if err != nil || boot.DbSslMode != "require" {
+		t.Fatal("expected sslmode require, got", boot, err)
+	}
+	if _, err = common.LoadBootConfig(bootDefaults(common.BootDb), []string{"--db-ssl-mode", "on"}); err == nil {
+		t.Fatal("expected an unknown sslmode to be rejected")
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/saichler/l8types/go/types/l8health"
+	"github.com/saichler/probler/go/prob/common"
+	"github.com/saichler/probler/go/prob/common/commands"
+	types2 "github.com/saichler/probler/go/types"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestDebugHosts(t *testing.T) {
+	top := &l8health.L8Top{}
+	err := protojson.Unmarshal([]byte(`{"healths":{
+		"a":{"alias":"parser-2","aUuid":"u2","services":{"serviceToAreas":{"Debug":{"areas":{"0":true}}}}},
+		"b":{"alias":"box-1","aUuid":"u1","services":{"serviceToAreas":{"Debug":{"areas":{"0":true}}}}},
+		"c":{"alias":"web","aUuid":"u3","services":{"serviceToAreas":{"NCache":{"areas":{"0":true}}}}}}}`), top)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hosts := common.DebugHosts(top, "")
+	if len(hosts) != 2 || hosts[0].Alias != "box-1" || hosts[1].Alias != "parser-2" {
+		t.Fatal("expected box-1 and parser-2, got", hosts)
+	}
+	if hosts = common.DebugHosts(top, "parser-2"); len(hosts) != 1 || hosts[0].AUuid != "u2" {
+		t.Fatal("expected parser-2 alone, got", hosts)
+	}
+	if hosts = common.DebugHosts(top, "web"); len(hosts) != 0 {
+		t.Fatal("web does not serve the debug service, got", hosts)
+	}
+}
+
+func TestApplyDebug(t *testing.T) {
+	ctl, err := commands.DebugTrace("", common.NetworkDevice_Links_ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply := common.ApplyDebug("box-1", ctl)
+	if reply.Error != "" || reply.Alias != "box-1" || !strings.Contains(reply.Message, common.NetworkDevice_Links_ID) {
+		t.Fatal("expected the links id to be traced, got", reply)
+	}
+	ctl, _ = commands.DebugTrace("", common.NetworkDevice_Links_ID, true)
+	if reply = common.ApplyDebug("box-1", ctl); reply.Error != "" || strings.Contains(reply.Message, common.NetworkDevice_Links_ID) {
+		t.Fatal("expected the trace to stop, got", reply)
+	}
+	reply = common.ApplyDebug("box-1", &types2.DebugControl{Action: types2.DebugAction_DEBUG_ACTION_TRACE, LinksId: "nope"})
+	if reply.Error == "" {
+		t.Fatal("expected an unknown links id to fail")
+	}
+
+	ctl, err = commands.DebugDump("box-1", commands.DumpGoroutines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply = common.ApplyDebug("box-1", ctl); reply.Error != "" || !strings.Contains(string(reply.Dump), "goroutine") {
+		t.Fatal("expected a goroutine dump, got", reply.Error)
+	}
+	if _, err = commands.DebugDump("", commands.DumpHeap); err == nil {
+		t.Fatal("expected a dump of every process to be refused")
+	}
+	if _, err = commands.DebugLogLevel("", "", "loud"); err == nil {
+		t.Fatal("expected an unknown log level to be refused")
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+	"github.com/saichler/probler/go/prob/common/creates"
+	"google.golang.org/protobuf/proto"
+)
+
+func createFleet(t *testing.T, spec *creates.FleetSpec) []*l8tpollaris.L8PTarget {
+	fleet, err := creates.CreateFleet(spec)
+	if err != nil {
+		t.Fatal(spec.Name, err)
+	}
+	return fleet
+}
+
+func TestFleetDeterministic(t *testing.T) {
+	spec := &creates.FleetSpec{Name: "lab", Seed: 42, Groups: []*creates.FleetGroup{
+		{Type: creates.FleetSwitch, Count: 300, Pool: "60.50.40.1/16", Protocols: map[string]float64{"snmp": 1, "ssh": 0.3, "rest": 0.2}},
+		{Type: creates.FleetGPU, Count: 20, Pool: "20.20.10.1/16"},
+		{Type: creates.FleetCluster, Count: 2, Protocols: map[string]float64{"k8s": 0.5, "kubectl": 0.5}},
+	}}
+	first := createFleet(t, spec)
+	second := createFleet(t, spec)
+	if len(first) != 322 || len(first) != len(second) {
+		t.Fatal("unexpected fleet size", len(first), len(second))
+	}
+	for i := range first {
+		if !proto.Equal(first[i], second[i]) {
+			t.Fatal("target", i, "differs between runs:", first[i].TargetId, second[i].TargetId)
+		}
+	}
+}
+
+func TestFleetSwitchAndRouterDiffer(t *testing.T) {
+	group := func(kind string) *creates.FleetSpec {
+		return &creates.FleetSpec{Name: kind, Seed: 7, Groups: []*creates.FleetGroup{
+			{Type: kind, Count: 50, Pool: "60.60.0.1/24"}}}
+	}
+	switches := createFleet(t, group(creates.FleetSwitch))
+	routers := createFleet(t, group(creates.FleetRouter))
+	same := 0
+	for i := range switches {
+		if proto.Equal(switches[i], routers[i]) {
+			same++
+		}
+	}
+	if same == len(switches) {
+		t.Fatal("switch and router groups generate identical targets")
+	}
+}
+
+func TestFleetPresetsDoNotOverlap(t *testing.T) {
+	owner := make(map[string]string)
+	for _, name := range creates.FleetPresetNames() {
+		if name == "all" {
+			continue
+		}
+		spec, err := creates.FleetPreset(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, target := range createFleet(t, spec) {
+			if other, ok := owner[target.TargetId]; ok {
+				t.Fatal("presets", other, "and", name, "both generate", target.TargetId)
+			}
+			owner[target.TargetId] = name
+		}
+	}
+	spec, err := creates.FleetPreset("all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	createFleet(t, spec)
+	if _, err = creates.FleetPreset("base,nope"); err == nil {
+		t.Fatal("expected an unknown preset to be rejected")
+	}
+}
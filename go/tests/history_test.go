@@ -0,0 +1,90 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/saichler/probler/go/prob/common"
+	"github.com/saichler/probler/go/prob/common/commands"
+	types2 "github.com/saichler/probler/go/types"
+)
+
+func TestClusterHistoryTier(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	for _, c := range []struct {
+		name string
+		from time.Time
+		step time.Duration
+	}{
+		{"last hour", now.Add(-time.Hour), 0},
+		{"edge of the recorded points", now.Add(-2 * day), 0},
+		{"just past the recorded points", now.Add(-2*day - time.Second), 5 * time.Minute},
+		{"last week", now.Add(-7 * day), 5 * time.Minute},
+		{"last month", now.Add(-30 * day), time.Hour},
+		{"past every retention", now.Add(-500 * day), time.Hour},
+		{"future", now.Add(time.Hour), 0},
+	} {
+		if tier := common.ClusterHistoryTier(c.from, now); tier.Step != c.step {
+			t.Error(c.name, ": expected step", c.step, "got", tier.Step)
+		}
+	}
+}
+
+func TestClusterHistoryRows(t *testing.T) {
+	series := &types2.ClusterSeries{Cluster: "c1", Metrics: map[string]*types2.SeriesPoints{
+		"total_nodes": {Points: []*types2.SeriesPoint{{Stamp: 120, Value: 3, Min: 3, Max: 3}}},
+		"ready_nodes": {Points: []*types2.SeriesPoint{
+			{Stamp: 120, Value: 2, Min: 2, Max: 2},
+			{Stamp: 60, Value: 3, Min: 3, Max: 3},
+		}},
+	}}
+	at := func(stamp int64) string {
+		return time.Unix(stamp, 0).Format(time.RFC3339)
+	}
+
+	// Columns follow ClusterMetrics, rows are sorted by stamp and a metric
+	// without a point at a stamp is blank.
+	header, rows := commands.ClusterHistoryRows(series, true)
+	if !reflect.DeepEqual(header, []string{"Time", "ready_nodes", "total_nodes"}) {
+		t.Fatal("unexpected header", header)
+	}
+	expected := [][]string{{at(60), "3", ""}, {at(120), "2", "3"}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Fatal("expected", expected, "got", rows)
+	}
+
+	// Rolled up points show min/avg/max when wide, the average otherwise.
+	series = &types2.ClusterSeries{Cluster: "c1", StepSeconds: 300, Metrics: map[string]*types2.SeriesPoints{
+		"ready_nodes": {Points: []*types2.SeriesPoint{{Stamp: 300, Value: 2.666, Min: 2, Max: 3}}},
+	}}
+	_, rows = commands.ClusterHistoryRows(series, true)
+	if !reflect.DeepEqual(rows, [][]string{{at(300), "2/2.67/3"}}) {
+		t.Fatal("unexpected wide rows", rows)
+	}
+	_, rows = commands.ClusterHistoryRows(series, false)
+	if !reflect.DeepEqual(rows, [][]string{{at(300), "2.67"}}) {
+		t.Fatal("unexpected rows", rows)
+	}
+
+	header, rows = commands.ClusterHistoryRows(&types2.ClusterSeries{Cluster: "c1"}, false)
+	if len(header) != 1 || len(rows) != 0 {
+		t.Fatal("expected an empty history, got", header, rows)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/saichler/l8pollaris/go/pollaris/targets"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8health"
+	"github.com/saichler/l8utils/go/utils/logger"
+	"github.com/saichler/l8utils/go/utils/registry"
+	"github.com/saichler/l8utils/go/utils/resources"
+	"github.com/saichler/probler/go/prob/common"
+	"github.com/saichler/probler/go/prob/common/commands"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestLinks(t *testing.T) {
+	log := logger.NewLoggerImpl(&logger.FmtLogMethod{})
+	log.SetLogLevel(ifs.Error_Level)
+	res := resources.NewResources(log)
+	res.Set(registry.NewRegistry())
+
+	err := common.ValidateLinks(res)
+	if err == nil || !strings.Contains(err.Error(), "model "+common.NetDev_Model_Name+" is not in the registry") {
+		t.Fatal("expected unregistered models to fail validation, got", err)
+	}
+	common.RegisterLinkModels(res)
+	if err = common.ValidateLinks(res); err != nil {
+		t.Fatal(err)
+	}
+
+	pdu := &common.LinkEntry{LinksId: "PDU", ParserName: "PPars", ParserArea: 3, CacheName: "PCache",
+		CacheArea: 3, PersistName: "PPersist", PersistArea: 3, Model: "pdudevice"}
+	if err := common.RegisterLink(pdu); err != nil {
+		t.Fatal(err)
+	}
+	if name, area := targets.Links.Cache("PDU"); name != "PCache" || area != 3 {
+		t.Fatal("runtime link not routed, got", name, area)
+	}
+	clash := &common.LinkEntry{LinksId: "UPS", ParserName: "PPars", ParserArea: 3, CacheName: "UCache",
+		PersistName: "UPersist", Model: "upsdevice"}
+	if err := common.RegisterLink(clash); err == nil {
+		t.Fatal("expected the UPS parser to clash with the PDU parser")
+	}
+	if err := common.ValidateLinks(res); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestK8sKinds(t *testing.T) {
+	seen := make(map[string]string)
+	for _, kind := range commands.K8sKinds {
+		for _, name := range append([]string{kind.Name}, kind.Aliases...) {
+			if other, ok := seen[name]; ok {
+				t.Fatal(name, "names both", other, "and", kind.LinksId)
+			}
+			seen[name] = kind.LinksId
+		}
+		if kind.List == nil {
+			t.Fatal("no list type for", kind.LinksId)
+		}
+	}
+	if kind, ok := commands.LookupK8sKind("PO"); !ok || kind.LinksId != common.K8sPod_Links_ID {
+		t.Fatal("po should resolve to pods")
+	}
+}
+
+func TestLinksTopology(t *testing.T) {
+	top := &l8health.L8Top{}
+	err := protojson.Unmarshal([]byte(`{"healths":{
+		"a":{"alias":"box-2","status":"Up","services":{"serviceToAreas":{"NCache":{"areas":{"0":true}}}}},
+		"b":{"alias":"box-1","status":"Down","services":{"serviceToAreas":{"NCache":{"areas":{"0":true}}}}},
+		"c":{"alias":"gpu","status":"Up","services":{"serviceToAreas":{"NCache":{"areas":{"2":true}}}}}}}`), top)
+	if err != nil {
+		t.Fatal(err)
+	}
+	routes, err := common.LinksTopology(top, []string{common.NetworkDevice_Links_ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != 1 || len(routes[0].Roles) != 4 {
+		t.Fatal("expected one route with four roles, got", routes)
+	}
+	cache := routes[0].Roles[2]
+	if cache.Role != common.LinkCache || cache.Replicas != 2 || cache.Up != 1 ||
+		strings.Join(cache.Hosts, ",") != "box-1,box-2" {
+		t.Fatal("unexpected cache role", cache)
+	}
+	if _, err = common.LinksTopology(top, []string{"NoSuchId"}); err == nil {
+		t.Fatal("expected an unknown links id to fail")
+	}
+}
@@ -0,0 +1,33 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/probler/go/prob/common/commands"
+)
+
+func TestLogsOptions(t *testing.T) {
+	if err := (&commands.LogsOptions{Tail: 20}).Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for _, opts := range []*commands.LogsOptions{{Container: "app"}, {Since: "10m"}, {Tail: -1}} {
+		if err := opts.Validate(); err == nil {
+			t.Fatal("expected", *opts, "to be refused")
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/probler/go/prob/common"
+	types3 "github.com/saichler/probler/go/types"
+	"google.golang.org/protobuf/proto"
+)
+
+func newPodMemoryService(t *testing.T) *common.MemoryService {
+	svc, err := common.NewMemoryService(&types3.K8SPod{}, &types3.K8SPodList{}, "ClusterName", "Key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+func storedPods(svc *common.MemoryService, match func(m proto.Message) bool) []*types3.K8SPod {
+	return svc.List(match).(*types3.K8SPodList).List
+}
+
+func TestMemoryServiceFields(t *testing.T) {
+	if _, err := common.NewMemoryService(&types3.K8SPod{}, &types3.K8SPodList{}, "clusterName", "KEY"); err != nil {
+		t.Fatal("expected the keys to match the field names in any case, got", err)
+	}
+	if _, err := common.NewMemoryService(&types3.K8SPod{}, &types3.K8SPodList{}, "PodName"); err == nil {
+		t.Fatal("expected an unknown primary key to be rejected")
+	}
+	if _, err := common.NewMemoryService(&types3.K8SPod{}, &types3.K8SPodList{}); err == nil {
+		t.Fatal("expected a service without primary keys to be rejected")
+	}
+	if _, err := common.NewMemoryService(&types3.K8SPod{}, &types3.K8SPod{}, "Key"); err == nil {
+		t.Fatal("expected a list type without a list field to be rejected")
+	}
+}
+
+func TestMemoryServiceKeys(t *testing.T) {
+	svc := newPodMemoryService(t)
+	for _, pod := range []*types3.K8SPod{
+		{ClusterName: "a/b", Key: "c", Name: "first"},
+		{ClusterName: "a", Key: "b/c", Name: "second"},
+		{ClusterName: "a", Key: "b/c", Name: "replaced"},
+	} {
+		if resp := svc.Post(object.New(nil, pod), nil); resp.Error() != nil {
+			t.Fatal(resp.Error())
+		}
+	}
+	pods := storedPods(svc, nil)
+	if len(pods) != 2 {
+		t.Fatal("expected two pods, got", pods)
+	}
+	if pods[0].Name != "replaced" || pods[1].Name != "first" {
+		t.Fatal("expected the post to replace the pod with the same keys, got", pods)
+	}
+	if resp := svc.Post(object.New(nil, &types3.K8SNode{}), nil); resp.Error() == nil ||
+		!strings.Contains(resp.Error().Error(), "expects") {
+		t.Fatal("expected a node to be rejected, got", resp.Error())
+	}
+	if resp := svc.Delete(object.New(nil, &types3.K8SPod{ClusterName: "a/b", Key: "c"}), nil); resp.Error() != nil {
+		t.Fatal(resp.Error())
+	}
+	if pods = storedPods(svc, nil); len(pods) != 1 || pods[0].Name != "replaced" {
+		t.Fatal("expected only the deleted pod to be removed, got", pods)
+	}
+}
+
+func TestMemoryServicePatch(t *testing.T) {
+	svc := newPodMemoryService(t)
+	svc.Post(object.New(nil, &types3.K8SPod{ClusterName: "c", Key: "k", Namespace: "ns", Name: "web"}), nil)
+	if resp := svc.Patch(object.New(nil, &types3.K8SPod{ClusterName: "c", Key: "k", Node: "n1"}), nil); resp.Error() != nil {
+		t.Fatal(resp.Error())
+	}
+	pods := storedPods(svc, nil)
+	if len(pods) != 1 || pods[0].Namespace != "ns" || pods[0].Name != "web" || pods[0].Node != "n1" {
+		t.Fatal("expected the node merged into the stored pod, got", pods)
+	}
+	svc.Patch(object.New(nil, &types3.K8SPod{ClusterName: "c", Key: "other", Name: "new"}), nil)
+	if pods = storedPods(svc, nil); len(pods) != 2 {
+		t.Fatal("expected a patch of an unknown pod to add it, got", pods)
+	}
+}
+
+func TestMemoryServiceList(t *testing.T) {
+	svc := newPodMemoryService(t)
+	for _, name := range []string{"api", "web", "db"} {
+		svc.Post(object.New(nil, &types3.K8SPod{ClusterName: "c", Key: name, Name: name, Namespace: "prod"}), nil)
+	}
+	svc.Post(object.New(nil, &types3.K8SPod{ClusterName: "c", Key: "dev-web", Name: "web", Namespace: "dev"}), nil)
+
+	pods := storedPods(svc, func(m proto.Message) bool {
+		return m.(*types3.K8SPod).Namespace == "prod"
+	})
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	if strings.Join(names, ",") != "api,db,web" {
+		t.Fatal("expected the prod pods sorted by key, got", names)
+	}
+	pods[0].Name = "changed"
+	if storedPods(svc, nil)[0].Name != "api" {
+		t.Fatal("expected the listed pods to be copies")
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/saichler/l8pollaris/go/types/l8tpollaris"
+	"github.com/saichler/probler/go/prob/common/output"
+	"sigs.k8s.io/yaml"
+)
+
+func outputTargets() *l8tpollaris.L8PTargetList {
+	return &l8tpollaris.L8PTargetList{List: []*l8tpollaris.L8PTarget{
+		{TargetId: "10.0.0.1", LinksId: "NetDev"},
+		{TargetId: "10.0.0.2", LinksId: "GPU"},
+	}}
+}
+
+func render(t *testing.T, format output.Format, columns []string, list *l8tpollaris.L8PTargetList) string {
+	buff := &bytes.Buffer{}
+	if err := output.NewPrinter(format, columns, nil, buff).Print(list); err != nil {
+		t.Fatal(err)
+	}
+	return buff.String()
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]output.Format{"": output.Table, "JSON": output.JSON, "wide": output.Wide, "csv": output.CSV} {
+		if got, err := output.ParseFormat(in); err != nil || got != want {
+			t.Fatal("format", in, "got", got, err)
+		}
+	}
+	if _, err := output.ParseFormat("xml"); err == nil {
+		t.Fatal("expected xml to be rejected")
+	}
+}
+
+func TestPrintTable(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(render(t, output.Table, []string{"TargetId", "LinksId"}, outputTargets())), "\n")
+	if len(lines) != 3 {
+		t.Fatal("expected a header and two rows, got", lines)
+	}
+	if fields := strings.Fields(lines[0]); len(fields) != 2 || fields[0] != "TARGETID" || fields[1] != "LINKSID" {
+		t.Fatal("unexpected header", lines[0])
+	}
+	if fields := strings.Fields(lines[2]); len(fields) != 2 || fields[0] != "10.0.0.2" || fields[1] != "GPU" {
+		t.Fatal("unexpected row", lines[2])
+	}
+}
+
+func TestPrintDefaultColumns(t *testing.T) {
+	table := render(t, output.Table, nil, outputTargets())
+	if !strings.Contains(table, "TARGETID") || !strings.Contains(table, "10.0.0.1") {
+		t.Fatal("default columns do not include the target id\n", table)
+	}
+	header := strings.Fields(strings.SplitN(render(t, output.Wide, nil, outputTargets()), "\n", 2)[0])
+	if narrow := strings.Fields(strings.SplitN(table, "\n", 2)[0]); len(narrow) > len(header) {
+		t.Fatal("table shows more columns than wide", narrow, header)
+	}
+}
+
+func TestPrintUnknownColumn(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(render(t, output.CSV, []string{"TargetId", "Hosts.Missing"}, outputTargets())), "\n")
+	if len(lines) != 3 || lines[0] != "TargetId,Hosts.Missing" || lines[1] != "10.0.0.1," {
+		t.Fatal("unexpected csv", lines)
+	}
+}
+
+func TestPrintEmpty(t *testing.T) {
+	if out := render(t, output.Table, nil, &l8tpollaris.L8PTargetList{}); strings.TrimSpace(out) != "No resources found" {
+		t.Fatal("unexpected output for an empty list", out)
+	}
+}
+
+func TestPrintJSONAndYAML(t *testing.T) {
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(render(t, output.JSON, nil, outputTargets())), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if list, ok := decoded["list"].([]interface{}); !ok || len(list) != 2 {
+		t.Fatal("unexpected json", decoded)
+	}
+	decoded = nil
+	if err := yaml.Unmarshal([]byte(render(t, output.YAML, nil, outputTargets())), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if list, ok := decoded["list"].([]interface{}); !ok || len(list) != 2 {
+		t.Fatal("unexpected yaml", decoded)
+	}
+}
+
+func TestPrintRows(t *testing.T) {
+	columns := []string{"Name", "Value"}
+	rows := [][]string{{"a", "1"}, {"b", "2"}}
+	value := map[string]int{"a": 1, "b": 2}
+
+	buff := &bytes.Buffer{}
+	if err := output.NewPrinter(output.CSV, nil, nil, buff).PrintRows(columns, rows, value); err != nil {
+		t.Fatal(err)
+	}
+	if buff.String() != "Name,Value\na,1\nb,2\n" {
+		t.Fatal("unexpected csv", buff.String())
+	}
+	buff.Reset()
+	if err := output.NewPrinter(output.JSON, nil, nil, buff).PrintRows(columns, rows, value); err != nil {
+		t.Fatal(err)
+	}
+	decoded := map[string]int{}
+	if err := json.Unmarshal(buff.Bytes(), &decoded); err != nil || decoded["b"] != 2 {
+		t.Fatal("unexpected json", buff.String(), err)
+	}
+	buff.Reset()
+	if err := output.NewPrinter(output.Table, nil, nil, buff).PrintRows(columns, nil, value); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(buff.String()) != "No resources found" {
+		t.Fatal("unexpected table", buff.String())
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8utils/go/utils/logger"
+	"github.com/saichler/l8utils/go/utils/resources"
+	"github.com/saichler/probler/go/prob/common"
+)
+
+func shutdownResources() ifs.IResources {
+	log := logger.NewLoggerImpl(&logger.FmtLogMethod{})
+	log.SetLogLevel(ifs.Error_Level)
+	return resources.NewResources(log)
+}
+
+// TestShutdownPhases checks every hook of a phase finishes before the next
+// phase starts, whatever order they were added in.
+func TestShutdownPhases(t *testing.T) {
+	hooks := common.NewShutdownHooks(5 * time.Second)
+	mtx := sync.Mutex{}
+	ran := make([]string, 0)
+	add := func(phase int, name string, delay time.Duration) {
+		hooks.Add(phase, name, func(ctx context.Context) error {
+			time.Sleep(delay)
+			mtx.Lock()
+			ran = append(ran, name)
+			mtx.Unlock()
+			return nil
+		})
+	}
+	add(common.ShutdownChildren, "postgres", 0)
+	add(common.ShutdownNetwork, "vnic", 0)
+	add(common.ShutdownFlush, "cache", 50*time.Millisecond)
+	add(common.ShutdownIntake, "collector", 50*time.Millisecond)
+	add(common.ShutdownIntake, "probes", 0)
+
+	if err := hooks.Run(shutdownResources()); err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(ran, ",")
+	if got != "probes,collector,cache,vnic,postgres" {
+		t.Fatal("unexpected hook order", got)
+	}
+}
+
+// TestShutdownDeadline checks a hook that ignores its context is abandoned at
+// the deadline and the later phases do not run.
+func TestShutdownDeadline(t *testing.T) {
+	hooks := common.NewShutdownHooks(100 * time.Millisecond)
+	hung := make(chan struct{})
+	defer close(hung)
+	hooks.Add(common.ShutdownFlush, "hung", func(ctx context.Context) error {
+		<-hung
+		return nil
+	})
+	network := false
+	hooks.Add(common.ShutdownNetwork, "vnic", func(ctx context.Context) error {
+		network = true
+		return nil
+	})
+
+	start := time.Now()
+	err := hooks.Run(shutdownResources())
+	if err == nil || !strings.Contains(err.Error(), "flush") {
+		t.Fatal("expected the deadline to pass during flush, got", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatal("the hung hook held the shutdown for", elapsed)
+	}
+	if network {
+		t.Fatal("the network phase ran after the deadline")
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/saichler/probler/go/prob/common/commands"
+)
+
+func TestParseIPRange(t *testing.T) {
+	cases := []struct {
+		r     string
+		match []string
+		miss  []string
+	}{
+		{"10.0.0.5", []string{"10.0.0.5"}, []string{"10.0.0.6", "host-a"}},
+		{"10.0.0.0/30", []string{"10.0.0.0", "10.0.0.3"}, []string{"10.0.0.4", "10.0.1.1"}},
+		{"10.0.0.10 - 10.0.0.20", []string{"10.0.0.10", "10.0.0.15", "10.0.0.20"}, []string{"10.0.0.9", "10.0.0.21"}},
+	}
+	for _, c := range cases {
+		inRange, err := commands.ParseIPRange(c.r)
+		if err != nil {
+			t.Fatal(c.r, err)
+		}
+		for _, ip := range c.match {
+			if !inRange(ip) {
+				t.Fatal(ip, "should be in", c.r)
+			}
+		}
+		for _, ip := range c.miss {
+			if inRange(ip) {
+				t.Fatal(ip, "should not be in", c.r)
+			}
+		}
+	}
+	for _, bad := range []string{"", "10.0.0", "10.0.0.0/33", "10.0.0.20-10.0.0.10", "10.0.0.1-x"} {
+		if _, err := commands.ParseIPRange(bad); err == nil {
+			t.Fatal("expected", bad, "to be rejected")
+		}
+	}
+}
+
+func TestIPRangeCondition(t *testing.T) {
+	cases := map[string]string{
+		"10.0.0.5":            "(TargetId=10.0.0.5)",
+		"10.0.0.0/31":         "(TargetId=10.0.0.0 or TargetId=10.0.0.1)",
+		"10.0.0.0/22":         "(TargetId=10.0.0.* or TargetId=10.0.1.* or TargetId=10.0.2.* or TargetId=10.0.3.*)",
+		"10.0.0.10-10.0.1.20": "(TargetId=10.0.0.* or TargetId=10.0.1.*)",
+		"10.0.0.0/8":          "(TargetId=10.*)",
+		"0.0.0.0/0":           "",
+		"fe80::/64":           "",
+	}
+	for r, want := range cases {
+		if got, err := commands.IPRangeCondition(r); err != nil || got != want {
+			t.Fatal(r, "got", got, err, "expected", want)
+		}
+	}
+	if _, err := commands.IPRangeCondition("10.0.0.9-10.0.0.1"); err == nil {
+		t.Fatal("expected a reversed range to be rejected")
+	}
+}
+
+func writeInventory(t *testing.T, name, data string) string {
+	filename := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filename, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestLoadInventoryYAML(t *testing.T) {
+	inv, err := commands.LoadInventory(writeInventory(t, "inv.yaml", `
+defaults:
+  credId: lab
+  protocols:
+    - name: snmp
+      port: 161
+targets:
+  - ip: 10.1.1.1
+    site: dc1
+    rack: r12
+  - ip: 10.1.1.2
+    type: gpu
+    credId: gpus
+    protocols:
+      - name: ssh
+        port: 22
+        timeout: 30
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inv.Targets) != 2 || inv.Defaults.CredId != "lab" || inv.Targets[1].Protocols[0].Timeout != 30 {
+		t.Fatal("unexpected inventory", inv)
+	}
+	if _, err = commands.LoadInventory(writeInventory(t, "bad.yaml", "targets:\n  - ip: 10.1.1.1\n    color: red\n")); err == nil {
+		t.Fatal("expected an unknown field to be rejected")
+	}
+	inv, err = commands.LoadInventory(writeInventory(t, "inv.json", `{"targets": [{"ip": "10.1.1.3", "credId": "lab"}]}`))
+	if err != nil || len(inv.Targets) != 1 || inv.Targets[0].IP != "10.1.1.3" {
+		t.Fatal("unexpected json inventory", inv, err)
+	}
+	if _, err = commands.LoadInventory(writeInventory(t, "inv.txt", "ip\n10.1.1.1\n")); err == nil {
+		t.Fatal("expected an unknown extension to be rejected")
+	}
+}
+
+func TestLoadInventoryCSV(t *testing.T) {
+	inv, err := commands.LoadInventory(writeInventory(t, "inv.csv",
+		"ip,type,credId,protocols,ports,timeout,site,rack\n"+
+			"# lab switches\n"+
+			"10.1.1.1,network,lab,snmp;ssh,161;22,30,dc1,r12\n"+
+			"10.1.1.2,gpu,gpus,ssh,,,dc1,r13\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inv.Targets) != 2 {
+		t.Fatal("expected two rows, got", len(inv.Targets))
+	}
+	row := inv.Targets[0]
+	if row.IP != "10.1.1.1" || row.Site != "dc1" || row.Rack != "r12" || len(row.Protocols) != 2 {
+		t.Fatal("unexpected row", row)
+	}
+	if row.Protocols[1].Name != "ssh" || row.Protocols[1].Port != 22 || row.Protocols[1].Timeout != 30 {
+		t.Fatal("unexpected protocol", row.Protocols[1])
+	}
+	if p := inv.Targets[1].Protocols[0]; p.Port != 0 || p.Timeout != 0 {
+		t.Fatal("unexpected defaults", p)
+	}
+	if _, err = commands.LoadInventory(writeInventory(t, "noip.csv", "host,type\na,network\n")); err == nil {
+		t.Fatal("expected a csv without an ip column to be rejected")
+	}
+}
+
+func TestBuildInventoryTargets(t *testing.T) {
+	inv, err := commands.LoadInventory(writeInventory(t, "inv.csv",
+		"ip,type,credId,protocols,ports,timeout\n"+
+			"10.1.1.1,network,lab,snmp,161\n"+
+			"# rows that fail, lines count the comment\n"+
+			"10.1.1.1,network,lab,snmp,161\n"+
+			"10.1.1.300,network,lab,snmp,161\n"+
+			"10.1.1.3,router,lab,snmp,161\n"+
+			"10.1.1.4,network,,snmp,161\n"+
+			"10.1.1.5,network,lab,snmp,x\n"+
+			"10.1.1.6,network,lab,snmp,161,soon\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	built, failures := commands.BuildInventoryTargets(inv)
+	if len(built) != 1 || built[0].TargetId != "10.1.1.1" {
+		t.Fatal("expected only the first row to build, got", built)
+	}
+	if len(failures) != 6 {
+		t.Fatal("expected six failed rows, got", failures)
+	}
+	if !strings.Contains(failures[5], `invalid timeout "soon"`) {
+		t.Fatal("expected the timeout to be reported, got", failures[5])
+	}
+	for i, want := range []string{"row 4", "row 5", "row 6", "row 7", "row 8", "row 9"} {
+		if !strings.HasPrefix(failures[i], want) {
+			t.Fatal("expected", want, "got", failures[i])
+		}
+	}
+}
@@ -0,0 +1,109 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saichler/l8types/go/types/l8health"
+	"github.com/saichler/probler/go/prob/common/commands"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// topOf builds an L8Top from alias -> "tx,rx,mem" counters.
+func topOf(t *testing.T, counters map[string][3]int) *l8health.L8Top {
+	entries := make([]string, 0, len(counters))
+	for alias, c := range counters {
+		entries = append(entries, fmt.Sprintf(`"%s":{"alias":"%s","stats":{"txMsgCount":"%d","rxMsgCount":"%d","memoryUsage":"%d"}}`,
+			alias, alias, c[0], c[1], c[2]))
+	}
+	top := &l8health.L8Top{}
+	if err := protojson.Unmarshal([]byte(`{"healths":{`+strings.Join(entries, ",")+`}}`), top); err != nil {
+		t.Fatal(err)
+	}
+	return top
+}
+
+// topRows returns the alias and Tx/s column of every rendered row.
+func topRows(rendered string) ([]string, map[string]string) {
+	lines := strings.Split(strings.TrimRight(rendered, "\n"), "\n")[1:]
+	aliases := make([]string, 0, len(lines))
+	rates := make(map[string]string)
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		aliases = append(aliases, fields[0])
+		rates[fields[0]] = fields[2]
+	}
+	return aliases, rates
+}
+
+func TestTopRates(t *testing.T) {
+	view, err := commands.NewTopView("tx", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1000, 0)
+	aliases, rates := topRows(view.Render(topOf(t, map[string][3]int{
+		"a": {100, 0, 1}, "b": {1000, 0, 1}, "restarted": {500, 0, 1}}), now))
+	if strings.Join(aliases, ",") != "b,restarted,a" || rates["a"] != "-" {
+		t.Fatal("first frame should sort by counters and show no rates", aliases, rates)
+	}
+
+	aliases, rates = topRows(view.Render(topOf(t, map[string][3]int{
+		"a": {600, 0, 1}, "b": {1100, 0, 1}, "restarted": {5, 0, 1}, "new": {100000, 0, 1}}), now.Add(10*time.Second)))
+	if rates["a"] != "50.0" || rates["b"] != "10.0" || rates["restarted"] != "-" || rates["new"] != "-" {
+		t.Fatal("unexpected rates", rates)
+	}
+	if strings.Join(aliases, ",") != "a,b,new,restarted" {
+		t.Fatal("processes without a rate must sort after those with one, got", aliases)
+	}
+}
+
+func TestTopSort(t *testing.T) {
+	top := topOf(t, map[string][3]int{"x": {1, 30, 200}, "y": {3, 20, 100}, "z": {2, 10, 300}})
+	for sortBy, want := range map[string]string{"": "z,x,y", "mem": "z,x,y", "tx": "y,z,x", "rx": "x,y,z", "alias": "x,y,z"} {
+		view, err := commands.NewTopView(sortBy, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if aliases, _ := topRows(view.Render(top, time.Unix(1000, 0))); strings.Join(aliases, ",") != want {
+			t.Fatal("sort", sortBy, "got", aliases, "expected", want)
+		}
+	}
+	if _, err := commands.NewTopView("disk", ""); err == nil {
+		t.Fatal("expected an unknown sort key to be rejected")
+	}
+}
+
+func TestTopFilter(t *testing.T) {
+	view, err := commands.NewTopView("alias", "^collector-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	top := topOf(t, map[string][3]int{"collector-1": {1, 1, 1}, "collector-2": {1, 1, 1}, "parser-1": {1, 1, 1}})
+	if filtered := view.Filter(top); len(filtered.Healths) != 2 {
+		t.Fatal("expected two collectors, got", len(filtered.Healths))
+	}
+	if aliases, _ := topRows(view.Render(top, time.Unix(1000, 0))); strings.Join(aliases, ",") != "collector-1,collector-2" {
+		t.Fatal("unexpected rows", aliases)
+	}
+	if _, err = commands.NewTopView("", "("); err == nil {
+		t.Fatal("expected an invalid filter to be rejected")
+	}
+}
@@ -0,0 +1,382 @@
+//
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v3.21.12
+// source: debug.proto
+
+package types
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DebugAction int32
+
+const (
+	DebugAction_DEBUG_ACTION_UNSPECIFIED DebugAction = 0
+	// Sets the log level of the process, or of one component of it.
+	DebugAction_DEBUG_ACTION_LOG_LEVEL DebugAction = 1
+	// Turns the routing trace of a links id on, no links id lists the traced ones.
+	DebugAction_DEBUG_ACTION_TRACE           DebugAction = 2
+	DebugAction_DEBUG_ACTION_UNTRACE         DebugAction = 3
+	DebugAction_DEBUG_ACTION_DUMP_GOROUTINES DebugAction = 4
+	// A heap profile in the go tool pprof format.
+	DebugAction_DEBUG_ACTION_DUMP_HEAP DebugAction = 5
+)
+
+// Enum value maps for DebugAction.
+var (
+	DebugAction_name = map[int32]string{
+		0: "DEBUG_ACTION_UNSPECIFIED",
+		1: "DEBUG_ACTION_LOG_LEVEL",
+		2: "DEBUG_ACTION_TRACE",
+		3: "DEBUG_ACTION_UNTRACE",
+		4: "DEBUG_ACTION_DUMP_GOROUTINES",
+		5: "DEBUG_ACTION_DUMP_HEAP",
+	}
+	DebugAction_value = map[string]int32{
+		"DEBUG_ACTION_UNSPECIFIED":     0,
+		"DEBUG_ACTION_LOG_LEVEL":       1,
+		"DEBUG_ACTION_TRACE":           2,
+		"DEBUG_ACTION_UNTRACE":         3,
+		"DEBUG_ACTION_DUMP_GOROUTINES": 4,
+		"DEBUG_ACTION_DUMP_HEAP":       5,
+	}
+)
+
+func (x DebugAction) Enum() *DebugAction {
+	p := new(DebugAction)
+	*p = x
+	return p
+}
+
+func (x DebugAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DebugAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_debug_proto_enumTypes[0].Descriptor()
+}
+
+func (DebugAction) Type() protoreflect.EnumType {
+	return &file_debug_proto_enumTypes[0]
+}
+
+func (x DebugAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DebugAction.Descriptor instead.
+func (DebugAction) EnumDescriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{0}
+}
+
+// A control for the debug service of one process, addressed by the alias the
+// health service reports for it, or of every process when alias is empty. A
+// component is the alias of one resources of the process, e.g. parser in the
+// all in one binary.
+type DebugControl struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Alias     string                 `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	Action    DebugAction            `protobuf:"varint,2,opt,name=action,proto3,enum=types.DebugAction" json:"action,omitempty"`
+	Component string                 `protobuf:"bytes,3,opt,name=component,proto3" json:"component,omitempty"`
+	Level     string                 `protobuf:"bytes,4,opt,name=level,proto3" json:"level,omitempty"`
+	LinksId   string                 `protobuf:"bytes,5,opt,name=links_id,json=linksId,proto3" json:"links_id,omitempty"`
+	// Set by the debug service that forwards the control to the process.
+	Forwarded     bool `protobuf:"varint,6,opt,name=forwarded,proto3" json:"forwarded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebugControl) Reset() {
+	*x = DebugControl{}
+	mi := &file_debug_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugControl) ProtoMessage() {}
+
+func (x *DebugControl) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugControl.ProtoReflect.Descriptor instead.
+func (*DebugControl) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DebugControl) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *DebugControl) GetAction() DebugAction {
+	if x != nil {
+		return x.Action
+	}
+	return DebugAction_DEBUG_ACTION_UNSPECIFIED
+}
+
+func (x *DebugControl) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *DebugControl) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *DebugControl) GetLinksId() string {
+	if x != nil {
+		return x.LinksId
+	}
+	return ""
+}
+
+func (x *DebugControl) GetForwarded() bool {
+	if x != nil {
+		return x.Forwarded
+	}
+	return false
+}
+
+type DebugReplyList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	List          []*DebugReply          `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebugReplyList) Reset() {
+	*x = DebugReplyList{}
+	mi := &file_debug_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugReplyList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugReplyList) ProtoMessage() {}
+
+func (x *DebugReplyList) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugReplyList.ProtoReflect.Descriptor instead.
+func (*DebugReplyList) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DebugReplyList) GetList() []*DebugReply {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+// The outcome of a control in one process.
+type DebugReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alias         string                 `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Dump          []byte                 `protobuf:"bytes,4,opt,name=dump,proto3" json:"dump,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebugReply) Reset() {
+	*x = DebugReply{}
+	mi := &file_debug_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugReply) ProtoMessage() {}
+
+func (x *DebugReply) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugReply.ProtoReflect.Descriptor instead.
+func (*DebugReply) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DebugReply) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *DebugReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DebugReply) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DebugReply) GetDump() []byte {
+	if x != nil {
+		return x.Dump
+	}
+	return nil
+}
+
+var File_debug_proto protoreflect.FileDescriptor
+
+const file_debug_proto_rawDesc = "" +
+	"\n" +
+	"\vdebug.proto\x12\x05types\"\xbd\x01\n" +
+	"\fDebugControl\x12\x14\n" +
+	"\x05alias\x18\x01 \x01(\tR\x05alias\x12*\n" +
+	"\x06action\x18\x02 \x01(\x0e2\x12.types.DebugActionR\x06action\x12\x1c\n" +
+	"\tcomponent\x18\x03 \x01(\tR\tcomponent\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\tR\x05level\x12\x19\n" +
+	"\blinks_id\x18\x05 \x01(\tR\alinksId\x12\x1c\n" +
+	"\tforwarded\x18\x06 \x01(\bR\tforwarded\"7\n" +
+	"\x0eDebugReplyList\x12%\n" +
+	"\x04list\x18\x01 \x03(\v2\x11.types.DebugReplyR\x04list\"f\n" +
+	"\n" +
+	"DebugReply\x12\x14\n" +
+	"\x05alias\x18\x01 \x01(\tR\x05alias\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x12\n" +
+	"\x04dump\x18\x04 \x01(\fR\x04dump*\xb7\x01\n" +
+	"\vDebugAction\x12\x1c\n" +
+	"\x18DEBUG_ACTION_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16DEBUG_ACTION_LOG_LEVEL\x10\x01\x12\x16\n" +
+	"\x12DEBUG_ACTION_TRACE\x10\x02\x12\x18\n" +
+	"\x14DEBUG_ACTION_UNTRACE\x10\x03\x12 \n" +
+	"\x1cDEBUG_ACTION_DUMP_GOROUTINES\x10\x04\x12\x1a\n" +
+	"\x16DEBUG_ACTION_DUMP_HEAP\x10\x05B\tZ\a./typesb\x06proto3"
+
+var (
+	file_debug_proto_rawDescOnce sync.Once
+	file_debug_proto_rawDescData []byte
+)
+
+func file_debug_proto_rawDescGZIP() []byte {
+	file_debug_proto_rawDescOnce.Do(func() {
+		file_debug_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_debug_proto_rawDesc), len(file_debug_proto_rawDesc)))
+	})
+	return file_debug_proto_rawDescData
+}
+
+var file_debug_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_debug_proto_goTypes = []any{
+	(DebugAction)(0),       // 0: types.DebugAction
+	(*DebugControl)(nil),   // 1: types.DebugControl
+	(*DebugReplyList)(nil), // 2: types.DebugReplyList
+	(*DebugReply)(nil),     // 3: types.DebugReply
+}
+var file_debug_proto_depIdxs = []int32{
+	0, // 0: types.DebugControl.action:type_name -> types.DebugAction
+	3, // 1: types.DebugReplyList.list:type_name -> types.DebugReply
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_debug_proto_init() }
+func file_debug_proto_init() {
+	if File_debug_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_debug_proto_rawDesc), len(file_debug_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_debug_proto_goTypes,
+		DependencyIndexes: file_debug_proto_depIdxs,
+		EnumInfos:         file_debug_proto_enumTypes,
+		MessageInfos:      file_debug_proto_msgTypes,
+	}.Build()
+	File_debug_proto = out.File
+	file_debug_proto_goTypes = nil
+	file_debug_proto_depIdxs = nil
+}
@@ -0,0 +1,299 @@
+//
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v3.21.12
+// source: links.proto
+
+package types
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The Links table entry of one links id joined with the live health of the
+// processes hosting its services, as served by the links service.
+type LinkRouteList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	List          []*LinkRoute           `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkRouteList) Reset() {
+	*x = LinkRouteList{}
+	mi := &file_links_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkRouteList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkRouteList) ProtoMessage() {}
+
+func (x *LinkRouteList) ProtoReflect() protoreflect.Message {
+	mi := &file_links_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkRouteList.ProtoReflect.Descriptor instead.
+func (*LinkRouteList) Descriptor() ([]byte, []int) {
+	return file_links_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LinkRouteList) GetList() []*LinkRoute {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+type LinkRoute struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LinksId       string                 `protobuf:"bytes,1,opt,name=links_id,json=linksId,proto3" json:"links_id,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Roles         []*LinkRole            `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkRoute) Reset() {
+	*x = LinkRoute{}
+	mi := &file_links_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkRoute) ProtoMessage() {}
+
+func (x *LinkRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_links_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkRoute.ProtoReflect.Descriptor instead.
+func (*LinkRoute) Descriptor() ([]byte, []int) {
+	return file_links_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LinkRoute) GetLinksId() string {
+	if x != nil {
+		return x.LinksId
+	}
+	return ""
+}
+
+func (x *LinkRoute) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *LinkRoute) GetRoles() []*LinkRole {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+// One service of a links id. Hosts are the aliases of the processes that
+// registered the service, up counts the ones the health service reports as up.
+type LinkRole struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Service       string                 `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Area          int32                  `protobuf:"varint,3,opt,name=area,proto3" json:"area,omitempty"`
+	Hosts         []string               `protobuf:"bytes,4,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	Replicas      int32                  `protobuf:"varint,5,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	Up            int32                  `protobuf:"varint,6,opt,name=up,proto3" json:"up,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkRole) Reset() {
+	*x = LinkRole{}
+	mi := &file_links_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkRole) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkRole) ProtoMessage() {}
+
+func (x *LinkRole) ProtoReflect() protoreflect.Message {
+	mi := &file_links_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkRole.ProtoReflect.Descriptor instead.
+func (*LinkRole) Descriptor() ([]byte, []int) {
+	return file_links_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LinkRole) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *LinkRole) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *LinkRole) GetArea() int32 {
+	if x != nil {
+		return x.Area
+	}
+	return 0
+}
+
+func (x *LinkRole) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *LinkRole) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *LinkRole) GetUp() int32 {
+	if x != nil {
+		return x.Up
+	}
+	return 0
+}
+
+var File_links_proto protoreflect.FileDescriptor
+
+const file_links_proto_rawDesc = "" +
+	"\n" +
+	"\vlinks.proto\x12\x05types\"5\n" +
+	"\rLinkRouteList\x12$\n" +
+	"\x04list\x18\x01 \x03(\v2\x10.types.LinkRouteR\x04list\"c\n" +
+	"\tLinkRoute\x12\x19\n" +
+	"\blinks_id\x18\x01 \x01(\tR\alinksId\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12%\n" +
+	"\x05roles\x18\x03 \x03(\v2\x0f.types.LinkRoleR\x05roles\"\x8e\x01\n" +
+	"\bLinkRole\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
+	"\aservice\x18\x02 \x01(\tR\aservice\x12\x12\n" +
+	"\x04area\x18\x03 \x01(\x05R\x04area\x12\x14\n" +
+	"\x05hosts\x18\x04 \x03(\tR\x05hosts\x12\x1a\n" +
+	"\breplicas\x18\x05 \x01(\x05R\breplicas\x12\x0e\n" +
+	"\x02up\x18\x06 \x01(\x05R\x02upB\tZ\a./typesb\x06proto3"
+
+var (
+	file_links_proto_rawDescOnce sync.Once
+	file_links_proto_rawDescData []byte
+)
+
+func file_links_proto_rawDescGZIP() []byte {
+	file_links_proto_rawDescOnce.Do(func() {
+		file_links_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_links_proto_rawDesc), len(file_links_proto_rawDesc)))
+	})
+	return file_links_proto_rawDescData
+}
+
+var file_links_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_links_proto_goTypes = []any{
+	(*LinkRouteList)(nil), // 0: types.LinkRouteList
+	(*LinkRoute)(nil),     // 1: types.LinkRoute
+	(*LinkRole)(nil),      // 2: types.LinkRole
+}
+var file_links_proto_depIdxs = []int32{
+	1, // 0: types.LinkRouteList.list:type_name -> types.LinkRoute
+	2, // 1: types.LinkRoute.roles:type_name -> types.LinkRole
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_links_proto_init() }
+func file_links_proto_init() {
+	if File_links_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_links_proto_rawDesc), len(file_links_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_links_proto_goTypes,
+		DependencyIndexes: file_links_proto_depIdxs,
+		MessageInfos:      file_links_proto_msgTypes,
+	}.Build()
+	File_links_proto = out.File
+	file_links_proto_goTypes = nil
+	file_links_proto_depIdxs = nil
+}
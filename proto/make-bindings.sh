@@ -13,6 +13,9 @@ docker run --user "$(id -u):$(id -g)" -e PROTO=kubernetes-networking-storage-rba
 docker run --user "$(id -u):$(id -g)" -e PROTO=protocols.proto --mount type=bind,source="$PWD",target=/home/proto/ -i saichler/protoc:latest
 docker run --user "$(id -u):$(id -g)" -e PROTO=inventory.proto --mount type=bind,source="$PWD",target=/home/proto/ -i saichler/protoc:latest
 docker run --user "$(id -u):$(id -g)" -e PROTO=gpu.proto --mount type=bind,source="$PWD",target=/home/proto/ -i saichler/protoc:latest
+docker run --user "$(id -u):$(id -g)" -e PROTO=links.proto --mount type=bind,source="$PWD",target=/home/proto/ -i saichler/protoc:latest
+docker run --user "$(id -u):$(id -g)" -e PROTO=history.proto --mount type=bind,source="$PWD",target=/home/proto/ -i saichler/protoc:latest
+docker run --user "$(id -u):$(id -g)" -e PROTO=debug.proto --mount type=bind,source="$PWD",target=/home/proto/ -i saichler/protoc:latest
 
 rm api.proto
 